@@ -316,6 +316,19 @@ func wrapContext(context *C.cairo_t) *Context {
 	return &Context{context}
 }
 
+// NewContext creates a gotk3 cairo Context from a pointer to a
+// C cairo_t.  This is primarily designed for use with other
+// gotk3 packages and should be avoided by applications.
+func NewContext(c uintptr, needsRef bool) *Context {
+	ptr := (*C.cairo_t)(unsafe.Pointer(c))
+	ctx := wrapContext(ptr)
+	if needsRef {
+		ctx.reference()
+	}
+	runtime.SetFinalizer(ctx, (*Context).destroy)
+	return ctx
+}
+
 // Create is a wrapper around cairo_create().
 func Create(target *Surface) *Context {
 	c := C.cairo_create(target.native())
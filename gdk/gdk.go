@@ -557,6 +557,14 @@ func marshalEvent(p uintptr) (interface{}, error) {
 	return &Event{(*C.GdkEvent)(unsafe.Pointer(c))}, nil
 }
 
+// WrapEvent creates a gotk3 gdk Event from a pointer to a C
+// GdkEvent.  The event is not copied or freed, so it is only valid while
+// the caller owning it is running.  This is primarily designed for use
+// with other gotk3 packages and should be avoided by applications.
+func WrapEvent(e uintptr) *Event {
+	return &Event{(*C.GdkEvent)(unsafe.Pointer(e))}
+}
+
 func (v *Event) free() {
 	C.gdk_event_free(v.native())
 }
@@ -777,8 +785,7 @@ func PixbufNewFromFile(filename string) (*Pixbuf, error) {
 	var err *C.GError
 	res := C.gdk_pixbuf_new_from_file((*C.char)(cstr), &err)
 	if res == nil {
-		defer C.g_error_free(err)
-		return nil, errors.New(C.GoString((*C.char)(err.message)))
+		return nil, glib.TakeError(unsafe.Pointer(err))
 	}
 	obj := &glib.Object{glib.ToGObject(unsafe.Pointer(res))}
 	p := &Pixbuf{obj}
@@ -262,10 +262,13 @@ func (v *Application) GetIsRemote() bool {
 //The “startup” signal is emitted if registration succeeds and application is the primary instance (including the non-unique case).
 //In the event of an error (such as cancellable being cancelled, or a failure to connect to the session bus), FALSE is returned and error is set appropriately.
 //Note: the return value of this function is not an indicator that this instance is or is not the primary instance of the application. See g_application_get_is_remote() for that.
-func (v *Application) Register(cancellable *Cancellable) bool {
-	var gerror **C.GError
-	c := C.g_application_register(v.native(), cancellable.native(), gerror)
-	return gobool(c)
+func (v *Application) Register(cancellable *Cancellable) (bool, error) {
+	var gerror *C.GError
+	c := C.g_application_register(v.native(), cancellable.native(), &gerror)
+	if !gobool(c) {
+		return false, glib.TakeError(unsafe.Pointer(gerror))
+	}
+	return true, nil
 }
 
 //void
@@ -7,6 +7,8 @@ package gio
 import "C"
 
 import (
+	"context"
+	"runtime"
 	"unsafe"
 
 	"github.com/terrak/gotk3/glib"
@@ -47,3 +49,94 @@ func (v *Cancellable) toCancellable() *C.GCancellable {
 	}
 	return C.toGCancellable(unsafe.Pointer(v.GObject))
 }
+
+//GCancellable *
+//g_cancellable_new (void);
+//Creates a new GCancellable object.
+//Applications that want to start one or more operations that should be cancellable should create a GCancellable and pass it to the operations.
+func CancellableNew() (*Cancellable, error) {
+	c := C.g_cancellable_new()
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := &glib.Object{glib.ToGObject(unsafe.Pointer(c))}
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return wrapCancellable(obj), nil
+}
+
+//gboolean
+//g_cancellable_is_cancelled (GCancellable *cancellable);
+//Checks if a cancellable job has been cancelled.
+func (v *Cancellable) IsCancelled() bool {
+	return gobool(C.g_cancellable_is_cancelled(v.native()))
+}
+
+//void
+//g_cancellable_cancel (GCancellable *cancellable);
+//Will set cancellable to cancelled, and will emit the “cancelled” signal.
+//This function is thread-safe. In other words, you can safely call it from a thread other than the one running the operation that was passed the cancellable .
+func (v *Cancellable) Cancel() {
+	C.g_cancellable_cancel(v.native())
+}
+
+//void
+//g_cancellable_reset (GCancellable *cancellable);
+//Resets cancellable to its uncancelled state.
+//If cancellable is currently in use by any cancellable operation then the behavior of this function is undefined.
+func (v *Cancellable) Reset() {
+	C.g_cancellable_reset(v.native())
+}
+
+// CancellableFromContext creates a new Cancellable which is cancelled when
+// ctx is done.  If ctx can never be done, as is the case for
+// context.Background(), the Cancellable is simply never cancelled by it.
+//
+// The returned stop function stops tying the Cancellable to ctx, and
+// should be called once the operation using the Cancellable has finished,
+// so that a long-lived ctx does not keep it alive.  It returns false if
+// the Cancellable has already been cancelled because ctx is done.
+func CancellableFromContext(ctx context.Context) (c *Cancellable, stop func() bool, err error) {
+	c, err = CancellableNew()
+	if err != nil {
+		return nil, nil, err
+	}
+	if ctx.Done() == nil {
+		return c, func() bool { return true }, nil
+	}
+	if ctx.Err() != nil {
+		c.Cancel()
+		return c, func() bool { return false }, nil
+	}
+	return c, context.AfterFunc(ctx, c.Cancel), nil
+}
+
+// Context returns a context derived from parent which is cancelled when
+// v is cancelled, in addition to when parent is done or the returned
+// CancelFunc is called.  The handler connected to v's "cancelled" signal
+// is disconnected once the returned context is done, so callers must
+// call the CancelFunc once they no longer need the context, as with
+// context.WithCancel, or the handler stays connected to v.
+func (v *Cancellable) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	if v.IsCancelled() {
+		cancel()
+		return ctx, cancel
+	}
+
+	handle, err := v.Connect("cancelled", func() {
+		cancel()
+	})
+	if err != nil {
+		cancel()
+		return ctx, cancel
+	}
+
+	// The signal may have fired between the check above and connecting.
+	if v.IsCancelled() {
+		cancel()
+	}
+	context.AfterFunc(ctx, func() {
+		v.HandlerDisconnect(handle)
+	})
+	return ctx, cancel
+}
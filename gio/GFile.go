@@ -219,15 +219,16 @@ func (v *File) GetParseName() string {
 
 //Loads the content of the file into memory. The data is always zero-terminated, but this is not included in the resultant length . The returned content should be freed with g_free() when no longer needed.
 //If cancellable is not NULL, then the operation can be cancelled by triggering the cancellable object from another thread. If the operation was cancelled, the error G_IO_ERROR_CANCELLED will be returned.
-func (v *File) LoadContents(cancellable *Cancellable) (text string, ok bool) {
-	contents := new(*C.char)
-	length := new(C.gsize)
-	c := C.g_file_load_contents(v.native(), cancellable.native(), contents, length, nil, nil)
-
-	ok = gobool(c)
-	text = C.GoStringN((*C.char)(*contents), (C.int)(*length))
-	C.g_free((C.gpointer)(contents))
-	return
+func (v *File) LoadContents(cancellable *Cancellable) (string, error) {
+	var contents *C.char
+	var length C.gsize
+	var gerror *C.GError
+	c := C.g_file_load_contents(v.native(), cancellable.native(), &contents, &length, nil, &gerror)
+	if !gobool(c) {
+		return "", glib.TakeError(unsafe.Pointer(gerror))
+	}
+	defer C.g_free(C.gpointer(contents))
+	return C.GoStringN(contents, C.int(length)), nil
 }
 
 //void	g_file_load_contents_async ()
@@ -0,0 +1,30 @@
+//GIOError
+package gio
+
+// #cgo pkg-config: gio-2.0 glib-2.0
+// #include <gio/gio.h>
+// #include "gio.go.h"
+import "C"
+
+import (
+	"github.com/terrak/gotk3/glib"
+)
+
+// ioError returns the sentinel error of the G_IO_ERROR domain for code.
+func ioError(code C.GIOErrorEnum) *glib.Error {
+	return glib.ErrorDomain(uint32(C.g_io_error_quark()), int(code))
+}
+
+// Sentinel errors of the G_IO_ERROR domain, for use with errors.Is.
+var (
+	ErrIOFailed           = ioError(C.G_IO_ERROR_FAILED)
+	ErrIONotFound         = ioError(C.G_IO_ERROR_NOT_FOUND)
+	ErrIOExists           = ioError(C.G_IO_ERROR_EXISTS)
+	ErrIOIsDirectory      = ioError(C.G_IO_ERROR_IS_DIRECTORY)
+	ErrIONotDirectory     = ioError(C.G_IO_ERROR_NOT_DIRECTORY)
+	ErrIOPermissionDenied = ioError(C.G_IO_ERROR_PERMISSION_DENIED)
+	ErrIONotSupported     = ioError(C.G_IO_ERROR_NOT_SUPPORTED)
+	ErrIOCancelled        = ioError(C.G_IO_ERROR_CANCELLED)
+	ErrIOTimedOut         = ioError(C.G_IO_ERROR_TIMED_OUT)
+	ErrIOBusy             = ioError(C.G_IO_ERROR_BUSY)
+)
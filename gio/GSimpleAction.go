@@ -72,10 +72,11 @@ func convertToSimpleAction(c *C.GSimpleAction) *SimpleAction {
 //                     const GVariantType *parameter_type);
 //Creates a new action.
 //The created action is stateless. See g_simple_action_new_stateful().
+//parameter_type may be nil for an action which takes no parameter.
 func SimpleActionNew( name string, parameter_type *glib.VariantType) *SimpleAction{
 	cstr := C.CString(name)
 	defer C.free(unsafe.Pointer(cstr))
-	c:=C.g_simple_action_new((*C.gchar)(cstr), (*C.GVariantType)(parameter_type.GVariantType))
+	c:=C.g_simple_action_new((*C.gchar)(cstr), (*C.GVariantType)(unsafe.Pointer(parameter_type.Native())))
 	obj := &glib.Object{glib.ToGObject(unsafe.Pointer(c))}
 	return wrapSimpleAction(obj)
 }
@@ -91,7 +92,7 @@ func SimpleActionNew( name string, parameter_type *glib.VariantType) *SimpleActi
 func SimpleActionNewStateful( name string, parameter_type *glib.VariantType, state *glib.Variant) *SimpleAction{
 	cstr := C.CString(name)
 	defer C.free(unsafe.Pointer(cstr))
-	c:=C.g_simple_action_new_stateful((*C.gchar)(cstr), (*C.GVariantType)(parameter_type.GVariantType), (*C.GVariant)(state.GVariant))
+	c:=C.g_simple_action_new_stateful((*C.gchar)(cstr), (*C.GVariantType)(unsafe.Pointer(parameter_type.Native())), (*C.GVariant)(unsafe.Pointer(state.Native())))
 	obj := &glib.Object{glib.ToGObject(unsafe.Pointer(c))}
 	return wrapSimpleAction(obj)
 }
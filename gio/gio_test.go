@@ -0,0 +1,98 @@
+package gio_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/terrak/gotk3/gio"
+)
+
+// waitCancelled waits for c to be cancelled, which context.AfterFunc does
+// from its own goroutine, and returns whether it was.
+func waitCancelled(c *gio.Cancellable) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if c.IsCancelled() {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return c.IsCancelled()
+}
+
+// TestCancellableFromContext ensures that the Cancellable is cancelled
+// with its context, unless stop was called first.
+func TestCancellableFromContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c, stop, err := gio.CancellableFromContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.IsCancelled() {
+		t.Fatal("cancellable cancelled before its context")
+	}
+	cancel()
+	if !waitCancelled(c) {
+		t.Error("cancellable not cancelled with its context")
+	}
+	if stop() {
+		t.Error("stop returned true after the context was cancelled")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	c, stop, err = gio.CancellableFromContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stop() {
+		t.Error("stop returned false before the context was cancelled")
+	}
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	if c.IsCancelled() {
+		t.Error("cancellable cancelled after stop")
+	}
+
+	c, stop, err = gio.CancellableFromContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.IsCancelled() || stop() {
+		t.Error("cancellable of a done context not cancelled at once")
+	}
+}
+
+// TestCancellableContext ensures that the context of a Cancellable is
+// cancelled with it, and with its parent.
+func TestCancellableContext(t *testing.T) {
+	c, err := gio.CancellableNew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := c.Context(context.Background())
+	defer cancel()
+	if ctx.Err() != nil {
+		t.Fatal("context done before its cancellable was cancelled")
+	}
+	c.Cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Error("context not done after its cancellable was cancelled")
+	}
+
+	c, err = gio.CancellableNew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel = c.Context(parent)
+	defer cancel()
+	cancelParent()
+	if ctx.Err() != context.Canceled {
+		t.Errorf("context error is %v after its parent was cancelled", ctx.Err())
+	}
+	if c.IsCancelled() {
+		t.Error("cancellable cancelled by the context derived from it")
+	}
+}
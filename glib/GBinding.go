@@ -0,0 +1,170 @@
+//GBinding : GBinding — Bind two object properties
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+)
+
+// BindingFlags is a representation of GLib's GBindingFlags.
+type BindingFlags int
+
+const (
+	BINDING_DEFAULT        BindingFlags = C.G_BINDING_DEFAULT
+	BINDING_BIDIRECTIONAL  BindingFlags = C.G_BINDING_BIDIRECTIONAL
+	BINDING_SYNC_CREATE    BindingFlags = C.G_BINDING_SYNC_CREATE
+	BINDING_INVERT_BOOLEAN BindingFlags = C.G_BINDING_INVERT_BOOLEAN
+)
+
+// BindingTransformFunc converts the value of one bound property, as
+// returned by GoValue, to a value for the other.  The result is converted
+// to the type of the other property if needed.  If ok is
+// false, the other property is left unchanged.
+type BindingTransformFunc func(from interface{}) (to interface{}, ok bool)
+
+/*
+ * GBinding
+ */
+
+// Binding is a representation of GLib's GBinding.
+type Binding struct {
+	*Object
+}
+
+// native returns a pointer to the underlying GBinding.
+func (v *Binding) native() *C.GBinding {
+	if v == nil || v.Object == nil {
+		return nil
+	}
+	return (*C.GBinding)(unsafe.Pointer(v.Object.native()))
+}
+
+// Native returns a pointer to the underlying GBinding.
+func (v *Binding) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// BindProperty is a wrapper around g_object_bind_property().  A non-nil
+// error is returned if the binding could not be created, for example
+// because either property does not exist.
+func (v *Object) BindProperty(sourceProperty string, target IObject, targetProperty string, flags BindingFlags) (*Binding, error) {
+	return v.BindPropertyFull(sourceProperty, target, targetProperty, flags, nil, nil)
+}
+
+// BindPropertyFull is a wrapper around
+// g_object_bind_property_with_closures().  transformTo converts values of
+// the source property for the target property, and transformFrom, which
+// is only used with BINDING_BIDIRECTIONAL, converts them back.  Either
+// may be nil to use the default conversion.
+func (v *Object) BindPropertyFull(sourceProperty string, target IObject, targetProperty string,
+	flags BindingFlags, transformTo, transformFrom BindingTransformFunc) (*Binding, error) {
+
+	cSource := C.CString(sourceProperty)
+	defer C.free(unsafe.Pointer(cSource))
+	cTarget := C.CString(targetProperty)
+	defer C.free(unsafe.Pointer(cTarget))
+
+	to, err := bindingTransformClosure(transformTo)
+	if err != nil {
+		return nil, err
+	}
+	from, err := bindingTransformClosure(transformFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	c := C.g_object_bind_property_with_closures(C.gpointer(v.native()),
+		(*C.gchar)(cSource), C.gpointer(target.toGObject()),
+		(*C.gchar)(cTarget), C.GBindingFlags(flags), to, from)
+	if c == nil {
+		return nil, errors.New("unable to bind property " + sourceProperty +
+			" to " + targetProperty)
+	}
+
+	// The binding is owned by the bound objects, so hold a reference in
+	// case it is used after they are finalized.
+	obj := newObject((*C.GObject)(unsafe.Pointer(c)))
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*Object).Unref)
+	return &Binding{obj}, nil
+}
+
+// bindingTransformClosure creates a GClosure for f suitable as a transform
+// function of g_object_bind_property_with_closures().  nil is returned if
+// f is nil.
+func bindingTransformClosure(f BindingTransformFunc) (*C.GClosure, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	// The GValues to convert are passed to the closure boxed in
+	// G_TYPE_VALUE GValues, so they are received as pointers.
+	closure, err := ClosureNew(func(_ *Object, fromValue, toValue uintptr) bool {
+		from := &Value{*(*C.GValue)(unsafe.Pointer(fromValue))}
+		val, err := from.GoValue()
+		if err != nil {
+			return false
+		}
+		res, ok := f(val)
+		if !ok {
+			return false
+		}
+		return storeGValue((*C.GValue)(unsafe.Pointer(toValue)), res) == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	C._g_closure_add_finalize_notifier(closure)
+	return closure, nil
+}
+
+// GetSource is a wrapper around g_binding_get_source().
+func (v *Binding) GetSource() *Object {
+	c := C.g_binding_get_source(v.native())
+	if c == nil {
+		return nil
+	}
+	obj := newObject(c)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*Object).Unref)
+	return obj
+}
+
+// GetTarget is a wrapper around g_binding_get_target().
+func (v *Binding) GetTarget() *Object {
+	c := C.g_binding_get_target(v.native())
+	if c == nil {
+		return nil
+	}
+	obj := newObject(c)
+	obj.RefSink()
+	runtime.SetFinalizer(obj, (*Object).Unref)
+	return obj
+}
+
+// GetSourceProperty is a wrapper around g_binding_get_source_property().
+func (v *Binding) GetSourceProperty() string {
+	return C.GoString((*C.char)(C.g_binding_get_source_property(v.native())))
+}
+
+// GetTargetProperty is a wrapper around g_binding_get_target_property().
+func (v *Binding) GetTargetProperty() string {
+	return C.GoString((*C.char)(C.g_binding_get_target_property(v.native())))
+}
+
+// GetFlags is a wrapper around g_binding_get_flags().
+func (v *Binding) GetFlags() BindingFlags {
+	return BindingFlags(C.g_binding_get_flags(v.native()))
+}
+
+// Unbind is a wrapper around g_binding_unbind().  After Unbind, changes
+// to either property are no longer propagated.
+func (v *Binding) Unbind() {
+	C.g_binding_unbind(v.native())
+}
@@ -0,0 +1,192 @@
+//GBytes : Byte Arrays — arrays of bytes
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// Bytes is a representation of GLib's GBytes, an immutable reference
+// counted byte buffer.
+//
+// Go code must follow the cgo pointer passing rules when sharing memory
+// with GLib: C code may only keep pointers to Go memory which has been
+// pinned.  BytesNew copies its data, so it is always safe.
+// BytesNewPinned instead pins the Go slice for the lifetime of the
+// GBytes, which avoids the copy of large buffers but requires that the
+// slice is not modified afterwards.
+type Bytes struct {
+	GBytes *C.GBytes
+}
+
+// native returns a pointer to the underlying GBytes.
+func (v *Bytes) native() *C.GBytes {
+	if v == nil {
+		return nil
+	}
+	return v.GBytes
+}
+
+// Native returns a pointer to the underlying GBytes.
+func (v *Bytes) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// takeBytes wraps a GBytes, taking ownership of the reference held by
+// the caller.
+func takeBytes(c *C.GBytes) *Bytes {
+	if c == nil {
+		return nil
+	}
+	b := &Bytes{c}
+	runtime.SetFinalizer(b, (*Bytes).unref)
+	return b
+}
+
+// TakeBytes wraps the GBytes pointed to by p, taking ownership of the
+// reference held by the caller.  It's exported for visibility to other
+// gotk3 packages and shouldn't be used in application code.
+func TakeBytes(p unsafe.Pointer) *Bytes {
+	return takeBytes((*C.GBytes)(p))
+}
+
+func (v *Bytes) unref() {
+	C.g_bytes_unref(v.native())
+}
+
+// BytesNew is a wrapper around g_bytes_new() and creates a GBytes holding
+// a copy of data.
+func BytesNew(data []byte) (*Bytes, error) {
+	var p C.gconstpointer
+	if len(data) > 0 {
+		p = C.gconstpointer(unsafe.Pointer(&data[0]))
+	}
+	c := C.g_bytes_new(p, C.gsize(len(data)))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return takeBytes(c), nil
+}
+
+// pinnedBytes holds the pinners of the Go slices shared with GBytes
+// created by BytesNewPinned, until GLib frees them.
+var pinnedBytes = struct {
+	sync.Mutex
+	m    map[uintptr]*runtime.Pinner
+	next uintptr
+}{
+	m: make(map[uintptr]*runtime.Pinner),
+}
+
+// BytesNewPinned is a wrapper around g_bytes_new_with_free_func() and
+// creates a GBytes sharing the memory of data without copying it.  data
+// is pinned until GLib releases the last reference to the GBytes, which
+// may be after the returned Bytes has been garbage collected if C code
+// holds its own reference.  data must not be modified while it is
+// pinned.
+func BytesNewPinned(data []byte) (*Bytes, error) {
+	if len(data) == 0 {
+		return BytesNew(nil)
+	}
+
+	pinner := new(runtime.Pinner)
+	pinner.Pin(&data[0])
+
+	pinnedBytes.Lock()
+	pinnedBytes.next++
+	id := pinnedBytes.next
+	pinnedBytes.m[id] = pinner
+	pinnedBytes.Unlock()
+
+	c := C._g_bytes_new_pinned(C.gconstpointer(unsafe.Pointer(&data[0])),
+		C.gsize(len(data)), C.guintptr(id))
+	if c == nil {
+		unpinBytes(id)
+		return nil, errNilPtr
+	}
+	return takeBytes(c), nil
+}
+
+//export goBytesFree
+func goBytesFree(userData C.gpointer) {
+	unpinBytes(uintptr(userData))
+}
+
+// unpinBytes unpins the Go slice of the GBytes created with id.
+func unpinBytes(id uintptr) {
+	pinnedBytes.Lock()
+	pinner := pinnedBytes.m[id]
+	delete(pinnedBytes.m, id)
+	pinnedBytes.Unlock()
+	if pinner != nil {
+		pinner.Unpin()
+	}
+}
+
+// NewFromBytes is a wrapper around g_bytes_new_from_bytes() and returns a
+// GBytes for length bytes of v starting at offset, sharing the memory of
+// v.
+func (v *Bytes) NewFromBytes(offset, length int) (*Bytes, error) {
+	if offset < 0 || length < 0 || offset+length > v.Size() {
+		return nil, errors.New("slice out of range of bytes")
+	}
+	c := C.g_bytes_new_from_bytes(v.native(), C.gsize(offset), C.gsize(length))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return takeBytes(c), nil
+}
+
+// Data returns a copy of the data of v.
+func (v *Bytes) Data() []byte {
+	var size C.gsize
+	p := C.g_bytes_get_data(v.native(), &size)
+	if size == 0 {
+		return []byte{}
+	}
+	return C.GoBytes(unsafe.Pointer(p), C.int(size))
+}
+
+// UnsafeData is a wrapper around g_bytes_get_data() and returns a slice
+// sharing the memory of v, without copying it.  The slice must not be
+// modified, and must not be used once v may have been garbage collected,
+// so callers should call runtime.KeepAlive(v) after their last use of it.
+func (v *Bytes) UnsafeData() []byte {
+	var size C.gsize
+	p := C.g_bytes_get_data(v.native(), &size)
+	if size == 0 {
+		return []byte{}
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(p)), int(size))
+}
+
+// Size is a wrapper around g_bytes_get_size().
+func (v *Bytes) Size() int {
+	return int(C.g_bytes_get_size(v.native()))
+}
+
+// Equal is a wrapper around g_bytes_equal().
+func (v *Bytes) Equal(other *Bytes) bool {
+	return gobool(C.g_bytes_equal(C.gconstpointer(unsafe.Pointer(v.native())),
+		C.gconstpointer(unsafe.Pointer(other.native()))))
+}
+
+// Compare is a wrapper around g_bytes_compare().  It returns a negative
+// value if v is less than other, zero if they are equal, and a positive
+// value if v is greater than other.
+func (v *Bytes) Compare(other *Bytes) int {
+	return int(C.g_bytes_compare(C.gconstpointer(unsafe.Pointer(v.native())),
+		C.gconstpointer(unsafe.Pointer(other.native()))))
+}
+
+// Hash is a wrapper around g_bytes_hash().
+func (v *Bytes) Hash() uint {
+	return uint(C.g_bytes_hash(C.gconstpointer(unsafe.Pointer(v.native()))))
+}
@@ -0,0 +1,363 @@
+//GDateTime : A structure representing Date and Time
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"runtime"
+	"time"
+	"unsafe"
+)
+
+var errDateRange = errors.New("date and time out of range")
+
+// DateTime is a representation of GLib's GDateTime, an immutable date and
+// time in a time zone, with microsecond precision.
+//
+// DateTimeFromTime and Time convert between DateTime and time.Time,
+// keeping the instant and the time zone, including its daylight saving
+// rules when both GLib and Go know it by name.  Nanoseconds are truncated
+// to microseconds, the precision of GDateTime.
+type DateTime struct {
+	GDateTime *C.GDateTime
+}
+
+// native returns a pointer to the underlying GDateTime.
+func (v *DateTime) native() *C.GDateTime {
+	if v == nil {
+		return nil
+	}
+	return v.GDateTime
+}
+
+// Native returns a pointer to the underlying GDateTime.
+func (v *DateTime) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// takeDateTime wraps a GDateTime, taking ownership of the reference held
+// by the caller.  GLib returns NULL for dates and times out of its
+// supported range, which is reported as an error.
+func takeDateTime(c *C.GDateTime) (*DateTime, error) {
+	if c == nil {
+		return nil, errDateRange
+	}
+	dt := &DateTime{c}
+	runtime.SetFinalizer(dt, (*DateTime).unref)
+	return dt, nil
+}
+
+// TakeDateTime wraps the GDateTime pointed to by p, taking ownership of
+// the reference held by the caller.  It's exported for visibility to other
+// gotk3 packages and shouldn't be used in application code.
+func TakeDateTime(p unsafe.Pointer) (*DateTime, error) {
+	return takeDateTime((*C.GDateTime)(p))
+}
+
+func (v *DateTime) unref() {
+	C.g_date_time_unref(v.native())
+}
+
+// DateTimeNew is a wrapper around g_date_time_new().  month is in the
+// range 1 to 12.
+func DateTimeNew(tz *TimeZone, year, month, day, hour, minute int, seconds float64) (*DateTime, error) {
+	defer runtime.KeepAlive(tz)
+	return takeDateTime(C.g_date_time_new(tz.native(), C.gint(year), C.gint(month),
+		C.gint(day), C.gint(hour), C.gint(minute), C.gdouble(seconds)))
+}
+
+// DateTimeNewLocal is a wrapper around g_date_time_new_local().
+func DateTimeNewLocal(year, month, day, hour, minute int, seconds float64) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_new_local(C.gint(year), C.gint(month),
+		C.gint(day), C.gint(hour), C.gint(minute), C.gdouble(seconds)))
+}
+
+// DateTimeNewUTC is a wrapper around g_date_time_new_utc().
+func DateTimeNewUTC(year, month, day, hour, minute int, seconds float64) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_new_utc(C.gint(year), C.gint(month),
+		C.gint(day), C.gint(hour), C.gint(minute), C.gdouble(seconds)))
+}
+
+// DateTimeNewNow is a wrapper around g_date_time_new_now().
+func DateTimeNewNow(tz *TimeZone) (*DateTime, error) {
+	defer runtime.KeepAlive(tz)
+	return takeDateTime(C.g_date_time_new_now(tz.native()))
+}
+
+// DateTimeNewNowLocal is a wrapper around g_date_time_new_now_local().
+func DateTimeNewNowLocal() (*DateTime, error) {
+	return takeDateTime(C.g_date_time_new_now_local())
+}
+
+// DateTimeNewNowUTC is a wrapper around g_date_time_new_now_utc().
+func DateTimeNewNowUTC() (*DateTime, error) {
+	return takeDateTime(C.g_date_time_new_now_utc())
+}
+
+// DateTimeNewFromUnixLocal is a wrapper around
+// g_date_time_new_from_unix_local().
+func DateTimeNewFromUnixLocal(t int64) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_new_from_unix_local(C.gint64(t)))
+}
+
+// DateTimeNewFromUnixUTC is a wrapper around
+// g_date_time_new_from_unix_utc().
+func DateTimeNewFromUnixUTC(t int64) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_new_from_unix_utc(C.gint64(t)))
+}
+
+// DateTimeFromTime returns the DateTime of the instant t.  The time zone
+// of the result is the GLib time zone named like the location of t, or a
+// time zone with the fixed UTC offset of t if GLib doesn't know the
+// location or disagrees with Go about its offset at t.
+func DateTimeFromTime(t time.Time) (*DateTime, error) {
+	tz, err := timeZoneFromLocation(t)
+	if err != nil {
+		return nil, err
+	}
+	utc, err := DateTimeNewFromUnixUTC(t.Unix())
+	if err != nil {
+		return nil, err
+	}
+	utc, err = utc.Add(time.Duration(t.Nanosecond()))
+	if err != nil {
+		return nil, err
+	}
+	return utc.ToTimeZone(tz)
+}
+
+// Time returns the instant of v as a time.Time.  The location of the
+// result is loaded with time.LoadLocation from the identifier of the time
+// zone of v, such as "Europe/Paris", so that its daylight saving rules
+// are kept.  If GLib is older than 2.58, which does not record
+// identifiers, or Go does not know the identifier or disagrees with GLib
+// about its offset, the location is a fixed zone with the abbreviation
+// and UTC offset of v.
+func (v *DateTime) Time() time.Time {
+	t := time.Unix(v.ToUnix(), int64(v.GetMicrosecond())*int64(time.Microsecond))
+	offset := int(v.GetUtcOffset() / time.Second)
+
+	if id := v.timeZoneIdentifier(); id != "" && id != "UTC" {
+		if loc, err := time.LoadLocation(id); err == nil {
+			if lt := t.In(loc); zoneOffset(lt) == offset {
+				return lt
+			}
+		}
+	}
+
+	name := v.GetTimezoneAbbreviation()
+	if offset == 0 && name == "UTC" {
+		return t.UTC()
+	}
+	return t.In(time.FixedZone(name, offset))
+}
+
+// timeZoneIdentifier returns the identifier of the time zone of v, or an
+// empty string if GLib does not provide it.
+func (v *DateTime) timeZoneIdentifier() string {
+	c := C._g_date_time_get_timezone_identifier(v.native())
+	if c == nil {
+		return ""
+	}
+	return C.GoString((*C.char)(c))
+}
+
+// zoneOffset returns the UTC offset of t, in seconds.
+func zoneOffset(t time.Time) int {
+	_, offset := t.Zone()
+	return offset
+}
+
+// Format is a wrapper around g_date_time_format().  format uses the
+// conversion specifications of GLib, such as "%Y-%m-%d".
+func (v *DateTime) Format(format string) (string, error) {
+	cstr := C.CString(format)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_date_time_format(v.native(), (*C.gchar)(cstr))
+	if c == nil {
+		return "", errors.New("invalid date and time format " + format)
+	}
+	return takeString(c), nil
+}
+
+// Add is a wrapper around g_date_time_add().  d is truncated to
+// microseconds.
+func (v *DateTime) Add(d time.Duration) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add(v.native(), C.GTimeSpan(d/time.Microsecond)))
+}
+
+// AddYears is a wrapper around g_date_time_add_years().
+func (v *DateTime) AddYears(years int) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add_years(v.native(), C.gint(years)))
+}
+
+// AddMonths is a wrapper around g_date_time_add_months().
+func (v *DateTime) AddMonths(months int) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add_months(v.native(), C.gint(months)))
+}
+
+// AddWeeks is a wrapper around g_date_time_add_weeks().
+func (v *DateTime) AddWeeks(weeks int) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add_weeks(v.native(), C.gint(weeks)))
+}
+
+// AddDays is a wrapper around g_date_time_add_days().
+func (v *DateTime) AddDays(days int) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add_days(v.native(), C.gint(days)))
+}
+
+// AddHours is a wrapper around g_date_time_add_hours().
+func (v *DateTime) AddHours(hours int) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add_hours(v.native(), C.gint(hours)))
+}
+
+// AddMinutes is a wrapper around g_date_time_add_minutes().
+func (v *DateTime) AddMinutes(minutes int) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add_minutes(v.native(), C.gint(minutes)))
+}
+
+// AddSeconds is a wrapper around g_date_time_add_seconds().
+func (v *DateTime) AddSeconds(seconds float64) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add_seconds(v.native(), C.gdouble(seconds)))
+}
+
+// AddFull is a wrapper around g_date_time_add_full().
+func (v *DateTime) AddFull(years, months, days, hours, minutes int, seconds float64) (*DateTime, error) {
+	return takeDateTime(C.g_date_time_add_full(v.native(), C.gint(years),
+		C.gint(months), C.gint(days), C.gint(hours), C.gint(minutes),
+		C.gdouble(seconds)))
+}
+
+// Difference is a wrapper around g_date_time_difference().  It returns
+// the duration from begin to v.
+func (v *DateTime) Difference(begin *DateTime) time.Duration {
+	return time.Duration(C.g_date_time_difference(v.native(), begin.native())) * time.Microsecond
+}
+
+// Compare is a wrapper around g_date_time_compare().  It returns -1, 0 or
+// 1 if v is before, at the same instant as, or after other.
+func (v *DateTime) Compare(other *DateTime) int {
+	return int(C.g_date_time_compare(C.gconstpointer(unsafe.Pointer(v.native())),
+		C.gconstpointer(unsafe.Pointer(other.native()))))
+}
+
+// Equal is a wrapper around g_date_time_equal().  DateTimes are equal if
+// they represent the same instant, whatever their time zones.
+func (v *DateTime) Equal(other *DateTime) bool {
+	return gobool(C.g_date_time_equal(C.gconstpointer(unsafe.Pointer(v.native())),
+		C.gconstpointer(unsafe.Pointer(other.native()))))
+}
+
+// GetYmd is a wrapper around g_date_time_get_ymd().  month is in the
+// range 1 to 12.
+func (v *DateTime) GetYmd() (year, month, day int) {
+	var cyear, cmonth, cday C.gint
+	C.g_date_time_get_ymd(v.native(), &cyear, &cmonth, &cday)
+	return int(cyear), int(cmonth), int(cday)
+}
+
+// GetYear is a wrapper around g_date_time_get_year().
+func (v *DateTime) GetYear() int {
+	return int(C.g_date_time_get_year(v.native()))
+}
+
+// GetMonth is a wrapper around g_date_time_get_month().  It returns a
+// month in the range 1 to 12.
+func (v *DateTime) GetMonth() int {
+	return int(C.g_date_time_get_month(v.native()))
+}
+
+// GetDayOfMonth is a wrapper around g_date_time_get_day_of_month().
+func (v *DateTime) GetDayOfMonth() int {
+	return int(C.g_date_time_get_day_of_month(v.native()))
+}
+
+// GetWeekNumberingYear is a wrapper around
+// g_date_time_get_week_numbering_year().
+func (v *DateTime) GetWeekNumberingYear() int {
+	return int(C.g_date_time_get_week_numbering_year(v.native()))
+}
+
+// GetWeekOfYear is a wrapper around g_date_time_get_week_of_year().
+func (v *DateTime) GetWeekOfYear() int {
+	return int(C.g_date_time_get_week_of_year(v.native()))
+}
+
+// GetDayOfWeek is a wrapper around g_date_time_get_day_of_week().  It
+// returns a day in the range 1 (Monday) to 7 (Sunday).
+func (v *DateTime) GetDayOfWeek() int {
+	return int(C.g_date_time_get_day_of_week(v.native()))
+}
+
+// GetDayOfYear is a wrapper around g_date_time_get_day_of_year().
+func (v *DateTime) GetDayOfYear() int {
+	return int(C.g_date_time_get_day_of_year(v.native()))
+}
+
+// GetHour is a wrapper around g_date_time_get_hour().
+func (v *DateTime) GetHour() int {
+	return int(C.g_date_time_get_hour(v.native()))
+}
+
+// GetMinute is a wrapper around g_date_time_get_minute().
+func (v *DateTime) GetMinute() int {
+	return int(C.g_date_time_get_minute(v.native()))
+}
+
+// GetSecond is a wrapper around g_date_time_get_second().
+func (v *DateTime) GetSecond() int {
+	return int(C.g_date_time_get_second(v.native()))
+}
+
+// GetMicrosecond is a wrapper around g_date_time_get_microsecond().
+func (v *DateTime) GetMicrosecond() int {
+	return int(C.g_date_time_get_microsecond(v.native()))
+}
+
+// GetSeconds is a wrapper around g_date_time_get_seconds().  It returns
+// the seconds of the minute, including their fractional part.
+func (v *DateTime) GetSeconds() float64 {
+	return float64(C.g_date_time_get_seconds(v.native()))
+}
+
+// ToUnix is a wrapper around g_date_time_to_unix().
+func (v *DateTime) ToUnix() int64 {
+	return int64(C.g_date_time_to_unix(v.native()))
+}
+
+// GetUtcOffset is a wrapper around g_date_time_get_utc_offset().
+func (v *DateTime) GetUtcOffset() time.Duration {
+	return time.Duration(C.g_date_time_get_utc_offset(v.native())) * time.Microsecond
+}
+
+// IsDaylightSavings is a wrapper around g_date_time_is_daylight_savings().
+func (v *DateTime) IsDaylightSavings() bool {
+	return gobool(C.g_date_time_is_daylight_savings(v.native()))
+}
+
+// GetTimezoneAbbreviation is a wrapper around
+// g_date_time_get_timezone_abbreviation().
+func (v *DateTime) GetTimezoneAbbreviation() string {
+	c := C.g_date_time_get_timezone_abbreviation(v.native())
+	return C.GoString((*C.char)(c))
+}
+
+// ToTimeZone is a wrapper around g_date_time_to_timezone().
+func (v *DateTime) ToTimeZone(tz *TimeZone) (*DateTime, error) {
+	defer runtime.KeepAlive(tz)
+	return takeDateTime(C.g_date_time_to_timezone(v.native(), tz.native()))
+}
+
+// ToLocal is a wrapper around g_date_time_to_local().
+func (v *DateTime) ToLocal() (*DateTime, error) {
+	return takeDateTime(C.g_date_time_to_local(v.native()))
+}
+
+// ToUTC is a wrapper around g_date_time_to_utc().
+func (v *DateTime) ToUTC() (*DateTime, error) {
+	return takeDateTime(C.g_date_time_to_utc(v.native()))
+}
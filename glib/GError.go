@@ -0,0 +1,108 @@
+//GError : Error Reporting — A system for reporting errors
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"strconv"
+	"unsafe"
+)
+
+// Quark is a representation of GLib's GQuark.
+type Quark uint32
+
+// QuarkFromString is a wrapper around g_quark_from_string().
+func QuarkFromString(s string) Quark {
+	cstr := C.CString(s)
+	defer C.free(unsafe.Pointer(cstr))
+	return Quark(C.g_quark_from_string((*C.gchar)(cstr)))
+}
+
+// String is a wrapper around g_quark_to_string().
+func (q Quark) String() string {
+	return C.GoString((*C.char)(C.g_quark_to_string(C.GQuark(q))))
+}
+
+// Error is a representation of GLib's GError.  Domain is the string of
+// the error domain quark, such as "g-io-error-quark".
+//
+// Errors compare equal with errors.Is if they have the same domain and
+// code, so the sentinel errors declared by this and other gotk3 packages
+// may be used to test for particular errors:
+//
+//	if errors.Is(err, gio.ErrIOCancelled) {
+//		return
+//	}
+type Error struct {
+	Domain  string
+	Code    int
+	Message string
+}
+
+// Error returns the message of e.
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Domain + ": error " + strconv.Itoa(e.Code)
+	}
+	return e.Message
+}
+
+// Is returns whether target is an *Error with the same domain and code as
+// e.  The messages are not compared.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Domain == e.Domain && t.Code == e.Code
+}
+
+// ErrorDomain returns an *Error without a message for the domain quark
+// and code, for use as a sentinel error.  It's exported for visibility to
+// other gotk3 packages and shouldn't be used in application code.
+func ErrorDomain(domain uint32, code int) *Error {
+	return &Error{Domain: Quark(domain).String(), Code: code}
+}
+
+// newError creates an *Error from a GError, which is not freed.
+func newError(err *C.GError) *Error {
+	return &Error{
+		Domain:  Quark(err.domain).String(),
+		Code:    int(err.code),
+		Message: C.GoString((*C.char)(err.message)),
+	}
+}
+
+// TakeError converts the GError pointed to by gerror to an *Error and
+// frees it.  nil is returned if gerror is nil.  It's exported for
+// visibility to other gotk3 packages and shouldn't be used in application
+// code.
+func TakeError(gerror unsafe.Pointer) error {
+	if gerror == nil {
+		return nil
+	}
+	err := (*C.GError)(gerror)
+	defer C.g_error_free(err)
+	return newError(err)
+}
+
+// Sentinel errors of the G_FILE_ERROR domain.
+var (
+	ErrFileExist  = ErrorDomain(uint32(C.g_file_error_quark()), C.G_FILE_ERROR_EXIST)
+	ErrFileIsDir  = ErrorDomain(uint32(C.g_file_error_quark()), C.G_FILE_ERROR_ISDIR)
+	ErrFileAccess = ErrorDomain(uint32(C.g_file_error_quark()), C.G_FILE_ERROR_ACCES)
+	ErrFileNoEnt  = ErrorDomain(uint32(C.g_file_error_quark()), C.G_FILE_ERROR_NOENT)
+	ErrFileNotDir = ErrorDomain(uint32(C.g_file_error_quark()), C.G_FILE_ERROR_NOTDIR)
+	ErrFileInval  = ErrorDomain(uint32(C.g_file_error_quark()), C.G_FILE_ERROR_INVAL)
+	ErrFileFailed = ErrorDomain(uint32(C.g_file_error_quark()), C.G_FILE_ERROR_FAILED)
+)
+
+// Sentinel errors of the G_KEY_FILE_ERROR domain.
+var (
+	ErrKeyFileUnknownEncoding = ErrorDomain(uint32(C.g_key_file_error_quark()), C.G_KEY_FILE_ERROR_UNKNOWN_ENCODING)
+	ErrKeyFileParse           = ErrorDomain(uint32(C.g_key_file_error_quark()), C.G_KEY_FILE_ERROR_PARSE)
+	ErrKeyFileNotFound        = ErrorDomain(uint32(C.g_key_file_error_quark()), C.G_KEY_FILE_ERROR_NOT_FOUND)
+	ErrKeyFileKeyNotFound     = ErrorDomain(uint32(C.g_key_file_error_quark()), C.G_KEY_FILE_ERROR_KEY_NOT_FOUND)
+	ErrKeyFileGroupNotFound   = ErrorDomain(uint32(C.g_key_file_error_quark()), C.G_KEY_FILE_ERROR_GROUP_NOT_FOUND)
+	ErrKeyFileInvalidValue    = ErrorDomain(uint32(C.g_key_file_error_quark()), C.G_KEY_FILE_ERROR_INVALID_VALUE)
+)
@@ -0,0 +1,145 @@
+//GHashTable : Hash Tables — associations between keys and values so that given a key the value can be found quickly
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import "unsafe"
+
+// HashTable is a representation of GLib's GHashTable.  As with List, its
+// keys and values are raw pointers and its memory is not managed by the
+// garbage collector: tables created by HashTableNew must be released with
+// Unref.
+type HashTable C.GHashTable
+
+// HashFunc selects the hash and equality functions of a HashTable.
+type HashFunc int
+
+const (
+	// HASH_DIRECT compares keys by pointer, as g_direct_hash() and
+	// g_direct_equal() do.
+	HASH_DIRECT HashFunc = iota
+
+	// HASH_STRING compares keys as nul-terminated strings, as
+	// g_str_hash() and g_str_equal() do.
+	HASH_STRING
+)
+
+// native returns a pointer to the underlying GHashTable.
+func (v *HashTable) native() *C.GHashTable {
+	return (*C.GHashTable)(unsafe.Pointer(v))
+}
+
+// Native returns a pointer to the underlying GHashTable.
+func (v *HashTable) Native() uintptr {
+	return uintptr(unsafe.Pointer(v))
+}
+
+// HashTableNew is a wrapper around g_hash_table_new_full().  If freeKeys
+// or freeValues is true, keys or values are freed with g_free() when they
+// are removed from the table.
+func HashTableNew(hash HashFunc, freeKeys, freeValues bool) (*HashTable, error) {
+	c := C._g_hash_table_new(gbool(hash == HASH_STRING), gbool(freeKeys), gbool(freeValues))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return (*HashTable)(unsafe.Pointer(c)), nil
+}
+
+// Ref is a wrapper around g_hash_table_ref().
+func (v *HashTable) Ref() *HashTable {
+	return (*HashTable)(unsafe.Pointer(C.g_hash_table_ref(v.native())))
+}
+
+// Unref is a wrapper around g_hash_table_unref().
+func (v *HashTable) Unref() {
+	C.g_hash_table_unref(v.native())
+}
+
+// Size is a wrapper around g_hash_table_size().
+func (v *HashTable) Size() uint {
+	return uint(C.g_hash_table_size(v.native()))
+}
+
+// Lookup is a wrapper around g_hash_table_lookup_extended().  It returns
+// the value of key and whether key is in the table, so that NULL values
+// can be told apart from missing keys.
+func (v *HashTable) Lookup(key uintptr) (uintptr, bool) {
+	var value C.gpointer
+	ok := C.g_hash_table_lookup_extended(v.native(), C.gconstpointer(key), nil, &value)
+	return uintptr(value), gobool(ok)
+}
+
+// Contains is a wrapper around g_hash_table_contains().
+func (v *HashTable) Contains(key uintptr) bool {
+	return gobool(C.g_hash_table_contains(v.native(), C.gconstpointer(key)))
+}
+
+// Insert is a wrapper around g_hash_table_insert().  If key is already in
+// the table, the new key is freed and the old one is kept.  It returns
+// whether key was not in the table yet.
+func (v *HashTable) Insert(key, value uintptr) bool {
+	return gobool(C.g_hash_table_insert(v.native(), C.gpointer(key), C.gpointer(value)))
+}
+
+// Replace is a wrapper around g_hash_table_replace().  If key is already
+// in the table, the old key is freed and replaced by the new one.  It
+// returns whether key was not in the table yet.
+func (v *HashTable) Replace(key, value uintptr) bool {
+	return gobool(C.g_hash_table_replace(v.native(), C.gpointer(key), C.gpointer(value)))
+}
+
+// Remove is a wrapper around g_hash_table_remove().
+func (v *HashTable) Remove(key uintptr) bool {
+	return gobool(C.g_hash_table_remove(v.native(), C.gconstpointer(key)))
+}
+
+// RemoveAll is a wrapper around g_hash_table_remove_all().
+func (v *HashTable) RemoveAll() {
+	C.g_hash_table_remove_all(v.native())
+}
+
+// Foreach calls f with each key and value of the table, using a
+// GHashTableIter, until f returns false.  The table must not be modified
+// by f.
+func (v *HashTable) Foreach(f func(key, value uintptr) bool) {
+	var iter C.GHashTableIter
+	var key, value C.gpointer
+	C.g_hash_table_iter_init(&iter, v.native())
+	for gobool(C.g_hash_table_iter_next(&iter, &key, &value)) {
+		if !f(uintptr(key), uintptr(value)) {
+			return
+		}
+	}
+}
+
+// HashTableToMap converts the entries of t to a map, calling wrapKey and
+// wrapValue with each key and value.  As with ListToSlice, the wrap
+// functions never take ownership of the data.
+//
+// Unlike lists, hash tables free their elements with their own destroy
+// functions.  With TRANSFER_FULL, the caller's reference to t is released
+// with g_hash_table_unref(), which frees the elements if t has destroy
+// functions.  With TRANSFER_CONTAINER, the elements are first stolen with
+// g_hash_table_steal_all() so that they are not freed.  t is left alone
+// with TRANSFER_NONE.
+func HashTableToMap[K comparable, V any](t *HashTable, transfer Transfer, wrapKey func(key uintptr) K, wrapValue func(value uintptr) V) map[K]V {
+	if t == nil {
+		return map[K]V{}
+	}
+	m := make(map[K]V, t.Size())
+	t.Foreach(func(key, value uintptr) bool {
+		m[wrapKey(key)] = wrapValue(value)
+		return true
+	})
+	switch transfer {
+	case TRANSFER_CONTAINER:
+		C.g_hash_table_steal_all(t.native())
+		t.Unref()
+	case TRANSFER_FULL:
+		t.Unref()
+	}
+	return m
+}
@@ -0,0 +1,271 @@
+//GIOChannel : IO Channels — portable support for using files, pipes and sockets
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"runtime"
+	"unsafe"
+)
+
+// IOStatus is a representation of GLib's GIOStatus.
+type IOStatus int
+
+const (
+	IO_STATUS_ERROR  IOStatus = C.G_IO_STATUS_ERROR
+	IO_STATUS_NORMAL IOStatus = C.G_IO_STATUS_NORMAL
+	IO_STATUS_EOF    IOStatus = C.G_IO_STATUS_EOF
+	IO_STATUS_AGAIN  IOStatus = C.G_IO_STATUS_AGAIN
+)
+
+// IOFlags is a representation of GLib's GIOFlags.
+type IOFlags int
+
+const (
+	IO_FLAG_APPEND      IOFlags = C.G_IO_FLAG_APPEND
+	IO_FLAG_NONBLOCK    IOFlags = C.G_IO_FLAG_NONBLOCK
+	IO_FLAG_IS_READABLE IOFlags = C.G_IO_FLAG_IS_READABLE
+	IO_FLAG_IS_WRITABLE IOFlags = C.G_IO_FLAG_IS_WRITABLE
+	IO_FLAG_IS_SEEKABLE IOFlags = C.G_IO_FLAG_IS_SEEKABLE
+)
+
+// Sentinel errors of the G_IO_CHANNEL_ERROR domain.
+var (
+	ErrIOChannelFBig     = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_FBIG)
+	ErrIOChannelInval    = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_INVAL)
+	ErrIOChannelIO       = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_IO)
+	ErrIOChannelIsDir    = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_ISDIR)
+	ErrIOChannelNoSpace  = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_NOSPC)
+	ErrIOChannelNXIO     = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_NXIO)
+	ErrIOChannelOverflow = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_OVERFLOW)
+	ErrIOChannelPipe     = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_PIPE)
+	ErrIOChannelFailed   = ErrorDomain(uint32(C.g_io_channel_error_quark()), C.G_IO_CHANNEL_ERROR_FAILED)
+)
+
+// IOChannel is a representation of GLib's GIOChannel.
+//
+// The read and write methods return the IOStatus of the operation along
+// with an error, which is only non-nil with IO_STATUS_ERROR.  Errors are
+// usually in the G_IO_CHANNEL_ERROR domain, or in the G_CONVERT_ERROR
+// domain if the data could not be converted from or to the encoding of
+// the channel.
+type IOChannel struct {
+	GIOChannel *C.GIOChannel
+}
+
+// native returns a pointer to the underlying GIOChannel.
+func (v *IOChannel) native() *C.GIOChannel {
+	if v == nil {
+		return nil
+	}
+	return v.GIOChannel
+}
+
+// Native returns a pointer to the underlying GIOChannel.
+func (v *IOChannel) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// takeIOChannel wraps a GIOChannel, taking ownership of the reference
+// held by the caller.
+func takeIOChannel(c *C.GIOChannel) *IOChannel {
+	ch := &IOChannel{c}
+	runtime.SetFinalizer(ch, (*IOChannel).unref)
+	return ch
+}
+
+func (v *IOChannel) unref() {
+	C.g_io_channel_unref(v.native())
+}
+
+// IOChannelNewUnix is a wrapper around g_io_channel_unix_new().  The
+// descriptor fd is not closed when the channel is freed, unless
+// SetCloseOnUnref is called.
+func IOChannelNewUnix(fd int) (*IOChannel, error) {
+	c := C.g_io_channel_unix_new(C.int(fd))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return takeIOChannel(c), nil
+}
+
+// IOChannelNewFile is a wrapper around g_io_channel_new_file().  mode is
+// one of "r", "w", "a", "r+", "w+" and "a+", as for fopen().  The file is
+// closed when the channel is freed.
+func IOChannelNewFile(filename, mode string) (*IOChannel, error) {
+	cfilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cfilename))
+	cmode := C.CString(mode)
+	defer C.free(unsafe.Pointer(cmode))
+
+	var gerr *C.GError
+	c := C.g_io_channel_new_file((*C.gchar)(cfilename), (*C.gchar)(cmode), &gerr)
+	if c == nil {
+		return nil, TakeError(unsafe.Pointer(gerr))
+	}
+	return takeIOChannel(c), nil
+}
+
+// ioStatus returns the status of an operation and its error, if any.
+func ioStatus(c C.GIOStatus, gerr *C.GError) (IOStatus, error) {
+	return IOStatus(c), TakeError(unsafe.Pointer(gerr))
+}
+
+// UnixGetFd is a wrapper around g_io_channel_unix_get_fd().
+func (v *IOChannel) UnixGetFd() int {
+	return int(C.g_io_channel_unix_get_fd(v.native()))
+}
+
+// ReadLine is a wrapper around g_io_channel_read_line().  The returned
+// line includes its terminator, unless the end of the file was reached
+// without one.  IO_STATUS_AGAIN is returned if the channel is
+// non-blocking and no complete line is available yet.
+func (v *IOChannel) ReadLine() (string, IOStatus, error) {
+	var str *C.gchar
+	var length C.gsize
+	var gerr *C.GError
+	c := C.g_io_channel_read_line(v.native(), &str, &length, nil, &gerr)
+	status, err := ioStatus(c, gerr)
+	if str == nil {
+		return "", status, err
+	}
+	defer C.g_free(C.gpointer(str))
+	return C.GoStringN((*C.char)(str), C.int(length)), status, err
+}
+
+// ReadChars is a wrapper around g_io_channel_read_chars().  It reads up
+// to len(buf) bytes into buf and returns the number of bytes read.
+func (v *IOChannel) ReadChars(buf []byte) (int, IOStatus, error) {
+	if len(buf) == 0 {
+		return 0, IO_STATUS_NORMAL, nil
+	}
+	var n C.gsize
+	var gerr *C.GError
+	c := C.g_io_channel_read_chars(v.native(), (*C.gchar)(unsafe.Pointer(&buf[0])),
+		C.gsize(len(buf)), &n, &gerr)
+	status, err := ioStatus(c, gerr)
+	return int(n), status, err
+}
+
+// WriteChars is a wrapper around g_io_channel_write_chars().  It returns
+// the number of bytes of data written, which may be less than len(data)
+// if the channel is non-blocking.
+func (v *IOChannel) WriteChars(data []byte) (int, IOStatus, error) {
+	if len(data) == 0 {
+		return 0, IO_STATUS_NORMAL, nil
+	}
+	var n C.gsize
+	var gerr *C.GError
+	c := C.g_io_channel_write_chars(v.native(), (*C.gchar)(unsafe.Pointer(&data[0])),
+		C.gssize(len(data)), &n, &gerr)
+	status, err := ioStatus(c, gerr)
+	return int(n), status, err
+}
+
+// Flush is a wrapper around g_io_channel_flush().
+func (v *IOChannel) Flush() (IOStatus, error) {
+	var gerr *C.GError
+	return ioStatus(C.g_io_channel_flush(v.native(), &gerr), gerr)
+}
+
+// Shutdown is a wrapper around g_io_channel_shutdown().  Pending data is
+// flushed first if flush is true.
+func (v *IOChannel) Shutdown(flush bool) (IOStatus, error) {
+	var gerr *C.GError
+	return ioStatus(C.g_io_channel_shutdown(v.native(), gbool(flush), &gerr), gerr)
+}
+
+// SetEncoding is a wrapper around g_io_channel_set_encoding().  An empty
+// encoding makes the channel binary, so that data is read and written
+// unchanged.  Channels use UTF-8 by default.
+func (v *IOChannel) SetEncoding(encoding string) error {
+	cstr := optCString(encoding)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var gerr *C.GError
+	c := C.g_io_channel_set_encoding(v.native(), cstr, &gerr)
+	if IOStatus(c) == IO_STATUS_ERROR {
+		return TakeError(unsafe.Pointer(gerr))
+	}
+	return nil
+}
+
+// GetEncoding is a wrapper around g_io_channel_get_encoding().  An empty
+// string is returned for binary channels.
+func (v *IOChannel) GetEncoding() string {
+	c := C.g_io_channel_get_encoding(v.native())
+	if c == nil {
+		return ""
+	}
+	return C.GoString((*C.char)(c))
+}
+
+// SetBuffered is a wrapper around g_io_channel_set_buffered().  Only
+// channels with an empty encoding may be unbuffered.
+func (v *IOChannel) SetBuffered(buffered bool) {
+	C.g_io_channel_set_buffered(v.native(), gbool(buffered))
+}
+
+// GetBuffered is a wrapper around g_io_channel_get_buffered().
+func (v *IOChannel) GetBuffered() bool {
+	return gobool(C.g_io_channel_get_buffered(v.native()))
+}
+
+// SetFlags is a wrapper around g_io_channel_set_flags().  Only
+// IO_FLAG_APPEND and IO_FLAG_NONBLOCK may be set.
+func (v *IOChannel) SetFlags(flags IOFlags) error {
+	var gerr *C.GError
+	c := C.g_io_channel_set_flags(v.native(), C.GIOFlags(flags), &gerr)
+	if IOStatus(c) == IO_STATUS_ERROR {
+		return TakeError(unsafe.Pointer(gerr))
+	}
+	return nil
+}
+
+// GetFlags is a wrapper around g_io_channel_get_flags().
+func (v *IOChannel) GetFlags() IOFlags {
+	return IOFlags(C.g_io_channel_get_flags(v.native()))
+}
+
+// SetCloseOnUnref is a wrapper around g_io_channel_set_close_on_unref().
+func (v *IOChannel) SetCloseOnUnref(doClose bool) {
+	C.g_io_channel_set_close_on_unref(v.native(), gbool(doClose))
+}
+
+// GetBufferCondition is a wrapper around
+// g_io_channel_get_buffer_condition().  It returns IO_IN if data is
+// already buffered for reading, and IO_OUT if the write buffer has room
+// for more data.
+func (v *IOChannel) GetBufferCondition() IOCondition {
+	return IOCondition(C.g_io_channel_get_buffer_condition(v.native()))
+}
+
+// IOAddWatch adds a source to the default main event loop context which
+// calls f whenever channel satisfies condition, using g_io_create_watch().
+// f is passed the channel and the conditions which are satisfied, which
+// may include IO_HUP and IO_ERR even if they were not requested.  The
+// source is removed when f returns false.  The channel is kept alive
+// while the source exists.
+func IOAddWatch(channel *IOChannel, condition IOCondition, f func(channel *IOChannel, condition IOCondition) bool) (SourceHandle, error) {
+	return IOAddWatchFull(channel, PRIORITY_DEFAULT, condition, f)
+}
+
+// IOAddWatchFull behaves like IOAddWatch, but runs f with the given
+// priority.
+func IOAddWatchFull(channel *IOChannel, priority Priority, condition IOCondition, f func(channel *IOChannel, condition IOCondition) bool) (SourceHandle, error) {
+	src := C.g_io_create_watch(channel.native(), C.GIOCondition(condition))
+	if src == nil {
+		return 0, errNilPtr
+	}
+	C.g_source_set_priority(src, C.gint(priority))
+
+	// GLib passes the channel as a G_TYPE_IO_CHANNEL boxed value, which
+	// reaches Go as a uintptr as no marshaler is registered for it, so
+	// the wrapper of the caller is passed to f instead.
+	return sourceAttachClosure(src, nil, func(_ uintptr, condition uint) bool {
+		return f(channel, IOCondition(condition))
+	})
+}
@@ -0,0 +1,547 @@
+//GKeyFile : Key-value file parser — parses .ini-like config files
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"runtime"
+	"unsafe"
+)
+
+// KeyFileFlags is a representation of GLib's GKeyFileFlags.
+type KeyFileFlags int
+
+const (
+	KEY_FILE_NONE              KeyFileFlags = C.G_KEY_FILE_NONE
+	KEY_FILE_KEEP_COMMENTS     KeyFileFlags = C.G_KEY_FILE_KEEP_COMMENTS
+	KEY_FILE_KEEP_TRANSLATIONS KeyFileFlags = C.G_KEY_FILE_KEEP_TRANSLATIONS
+)
+
+// KeyFile is a representation of GLib's GKeyFile.  Errors returned by its
+// methods are of type *Error, and may be compared against the
+// ErrKeyFile* and ErrFile* sentinels with errors.Is.
+type KeyFile struct {
+	GKeyFile *C.GKeyFile
+}
+
+// native returns a pointer to the underlying GKeyFile.
+func (v *KeyFile) native() *C.GKeyFile {
+	if v == nil {
+		return nil
+	}
+	return v.GKeyFile
+}
+
+// Native returns a pointer to the underlying GKeyFile.
+func (v *KeyFile) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// KeyFileNew is a wrapper around g_key_file_new().
+func KeyFileNew() (*KeyFile, error) {
+	c := C.g_key_file_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	kf := &KeyFile{c}
+	runtime.SetFinalizer(kf, (*KeyFile).unref)
+	return kf, nil
+}
+
+func (v *KeyFile) unref() {
+	C.g_key_file_unref(v.native())
+}
+
+// optCString returns a C copy of s, or nil if s is empty, for the
+// optional string arguments of GLib functions.  The result must be
+// released with C.free.
+func optCString(s string) *C.gchar {
+	if s == "" {
+		return nil
+	}
+	return (*C.gchar)(C.CString(s))
+}
+
+// SetListSeparator is a wrapper around g_key_file_set_list_separator().
+func (v *KeyFile) SetListSeparator(separator byte) {
+	C.g_key_file_set_list_separator(v.native(), C.gchar(separator))
+}
+
+// LoadFromFile is a wrapper around g_key_file_load_from_file().
+func (v *KeyFile) LoadFromFile(file string, flags KeyFileFlags) error {
+	cstr := C.CString(file)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var err *C.GError
+	C.g_key_file_load_from_file(v.native(), (*C.gchar)(cstr), C.GKeyFileFlags(flags), &err)
+	return TakeError(unsafe.Pointer(err))
+}
+
+// LoadFromData is a wrapper around g_key_file_load_from_data().
+func (v *KeyFile) LoadFromData(data string, flags KeyFileFlags) error {
+	cstr := C.CString(data)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var err *C.GError
+	C.g_key_file_load_from_data(v.native(), (*C.gchar)(cstr), C.gsize(len(data)),
+		C.GKeyFileFlags(flags), &err)
+	return TakeError(unsafe.Pointer(err))
+}
+
+// LoadFromDirs is a wrapper around g_key_file_load_from_dirs().  It loads
+// the first file called file found in searchDirs, and returns its full
+// path.
+func (v *KeyFile) LoadFromDirs(file string, searchDirs []string, flags KeyFileFlags) (string, error) {
+	cstr := C.CString(file)
+	defer C.free(unsafe.Pointer(cstr))
+	dirs := cStrv(searchDirs)
+	defer freeStrv(dirs, len(searchDirs))
+
+	var fullPath *C.gchar
+	var err *C.GError
+	C.g_key_file_load_from_dirs(v.native(), (*C.gchar)(cstr), dirs, &fullPath,
+		C.GKeyFileFlags(flags), &err)
+	if err != nil {
+		return "", TakeError(unsafe.Pointer(err))
+	}
+	defer C.g_free(C.gpointer(fullPath))
+	return C.GoString((*C.char)(fullPath)), nil
+}
+
+// ToData is a wrapper around g_key_file_to_data().
+func (v *KeyFile) ToData() (string, error) {
+	var length C.gsize
+	var err *C.GError
+	c := C.g_key_file_to_data(v.native(), &length, &err)
+	if c == nil {
+		return "", TakeError(unsafe.Pointer(err))
+	}
+	defer C.g_free(C.gpointer(c))
+	return C.GoStringN((*C.char)(c), C.int(length)), nil
+}
+
+// SaveToFile is a wrapper around g_key_file_save_to_file().
+func (v *KeyFile) SaveToFile(filename string) error {
+	cstr := C.CString(filename)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var err *C.GError
+	C.g_key_file_save_to_file(v.native(), (*C.gchar)(cstr), &err)
+	return TakeError(unsafe.Pointer(err))
+}
+
+// GetStartGroup is a wrapper around g_key_file_get_start_group().
+func (v *KeyFile) GetStartGroup() string {
+	c := C.g_key_file_get_start_group(v.native())
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
+// GetGroups is a wrapper around g_key_file_get_groups().
+func (v *KeyFile) GetGroups() []string {
+	var length C.gsize
+	c := C.g_key_file_get_groups(v.native(), &length)
+	defer C.g_strfreev(c)
+	return goStrv(c, int(length))
+}
+
+// GetKeys is a wrapper around g_key_file_get_keys().
+func (v *KeyFile) GetKeys(group string) ([]string, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+
+	var length C.gsize
+	var err *C.GError
+	c := C.g_key_file_get_keys(v.native(), (*C.gchar)(cgroup), &length, &err)
+	if c == nil {
+		return nil, TakeError(unsafe.Pointer(err))
+	}
+	defer C.g_strfreev(c)
+	return goStrv(c, int(length)), nil
+}
+
+// HasGroup is a wrapper around g_key_file_has_group().
+func (v *KeyFile) HasGroup(group string) bool {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	return gobool(C.g_key_file_has_group(v.native(), (*C.gchar)(cgroup)))
+}
+
+// HasKey is a wrapper around g_key_file_has_key().  A non-nil error is
+// returned if group does not exist.
+func (v *KeyFile) HasKey(group, key string) (bool, error) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+
+	var err *C.GError
+	c := C.g_key_file_has_key(v.native(), (*C.gchar)(cgroup), (*C.gchar)(ckey), &err)
+	if err != nil {
+		return false, TakeError(unsafe.Pointer(err))
+	}
+	return gobool(c), nil
+}
+
+// keyFileGet calls get with C copies of group and key, and returns the
+// error it sets.
+func keyFileGet(group, key string, get func(group, key *C.gchar, err **C.GError)) error {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+
+	var err *C.GError
+	get((*C.gchar)(cgroup), (*C.gchar)(ckey), &err)
+	return TakeError(unsafe.Pointer(err))
+}
+
+// keyFileSet calls set with C copies of group and key.
+func keyFileSet(group, key string, set func(group, key *C.gchar)) {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	set((*C.gchar)(cgroup), (*C.gchar)(ckey))
+}
+
+// takeString copies and frees a string returned by GLib.
+func takeString(c *C.gchar) string {
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
+// GetValue is a wrapper around g_key_file_get_value().  The raw value is
+// returned, without unescaping.
+func (v *KeyFile) GetValue(group, key string) (string, error) {
+	var s string
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		s = takeString(C.g_key_file_get_value(v.native(), g, k, err))
+	})
+	return s, err
+}
+
+// SetValue is a wrapper around g_key_file_set_value().
+func (v *KeyFile) SetValue(group, key, value string) {
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_value(v.native(), g, k, (*C.gchar)(cvalue))
+	})
+}
+
+// GetString is a wrapper around g_key_file_get_string().
+func (v *KeyFile) GetString(group, key string) (string, error) {
+	var s string
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		s = takeString(C.g_key_file_get_string(v.native(), g, k, err))
+	})
+	return s, err
+}
+
+// SetString is a wrapper around g_key_file_set_string().
+func (v *KeyFile) SetString(group, key, str string) {
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_string(v.native(), g, k, (*C.gchar)(cstr))
+	})
+}
+
+// GetLocaleString is a wrapper around g_key_file_get_locale_string().
+// If locale is empty, the current locale is used.
+func (v *KeyFile) GetLocaleString(group, key, locale string) (string, error) {
+	clocale := optCString(locale)
+	defer C.free(unsafe.Pointer(clocale))
+
+	var s string
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		s = takeString(C.g_key_file_get_locale_string(v.native(), g, k, clocale, err))
+	})
+	return s, err
+}
+
+// SetLocaleString is a wrapper around g_key_file_set_locale_string().
+func (v *KeyFile) SetLocaleString(group, key, locale, str string) {
+	clocale := C.CString(locale)
+	defer C.free(unsafe.Pointer(clocale))
+	cstr := C.CString(str)
+	defer C.free(unsafe.Pointer(cstr))
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_locale_string(v.native(), g, k, (*C.gchar)(clocale), (*C.gchar)(cstr))
+	})
+}
+
+// GetBoolean is a wrapper around g_key_file_get_boolean().
+func (v *KeyFile) GetBoolean(group, key string) (bool, error) {
+	var b bool
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		b = gobool(C.g_key_file_get_boolean(v.native(), g, k, err))
+	})
+	return b, err
+}
+
+// SetBoolean is a wrapper around g_key_file_set_boolean().
+func (v *KeyFile) SetBoolean(group, key string, value bool) {
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_boolean(v.native(), g, k, gbool(value))
+	})
+}
+
+// GetInteger is a wrapper around g_key_file_get_integer().
+func (v *KeyFile) GetInteger(group, key string) (int, error) {
+	var i int
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		i = int(C.g_key_file_get_integer(v.native(), g, k, err))
+	})
+	return i, err
+}
+
+// SetInteger is a wrapper around g_key_file_set_integer().
+func (v *KeyFile) SetInteger(group, key string, value int) {
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_integer(v.native(), g, k, C.gint(value))
+	})
+}
+
+// GetInt64 is a wrapper around g_key_file_get_int64().
+func (v *KeyFile) GetInt64(group, key string) (int64, error) {
+	var i int64
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		i = int64(C.g_key_file_get_int64(v.native(), g, k, err))
+	})
+	return i, err
+}
+
+// SetInt64 is a wrapper around g_key_file_set_int64().
+func (v *KeyFile) SetInt64(group, key string, value int64) {
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_int64(v.native(), g, k, C.gint64(value))
+	})
+}
+
+// GetUint64 is a wrapper around g_key_file_get_uint64().
+func (v *KeyFile) GetUint64(group, key string) (uint64, error) {
+	var i uint64
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		i = uint64(C.g_key_file_get_uint64(v.native(), g, k, err))
+	})
+	return i, err
+}
+
+// SetUint64 is a wrapper around g_key_file_set_uint64().
+func (v *KeyFile) SetUint64(group, key string, value uint64) {
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_uint64(v.native(), g, k, C.guint64(value))
+	})
+}
+
+// GetDouble is a wrapper around g_key_file_get_double().
+func (v *KeyFile) GetDouble(group, key string) (float64, error) {
+	var f float64
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		f = float64(C.g_key_file_get_double(v.native(), g, k, err))
+	})
+	return f, err
+}
+
+// SetDouble is a wrapper around g_key_file_set_double().
+func (v *KeyFile) SetDouble(group, key string, value float64) {
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_double(v.native(), g, k, C.gdouble(value))
+	})
+}
+
+// GetStringList is a wrapper around g_key_file_get_string_list().
+func (v *KeyFile) GetStringList(group, key string) ([]string, error) {
+	var list []string
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		var length C.gsize
+		c := C.g_key_file_get_string_list(v.native(), g, k, &length, err)
+		defer C.g_strfreev(c)
+		list = goStrv(c, int(length))
+	})
+	return list, err
+}
+
+// SetStringList is a wrapper around g_key_file_set_string_list().
+func (v *KeyFile) SetStringList(group, key string, list []string) {
+	strv := cStrv(list)
+	defer freeStrv(strv, len(list))
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_string_list(v.native(), g, k, strv, C.gsize(len(list)))
+	})
+}
+
+// GetLocaleStringList is a wrapper around
+// g_key_file_get_locale_string_list().  If locale is empty, the current
+// locale is used.
+func (v *KeyFile) GetLocaleStringList(group, key, locale string) ([]string, error) {
+	clocale := optCString(locale)
+	defer C.free(unsafe.Pointer(clocale))
+
+	var list []string
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		var length C.gsize
+		c := C.g_key_file_get_locale_string_list(v.native(), g, k, clocale, &length, err)
+		defer C.g_strfreev(c)
+		list = goStrv(c, int(length))
+	})
+	return list, err
+}
+
+// SetLocaleStringList is a wrapper around
+// g_key_file_set_locale_string_list().
+func (v *KeyFile) SetLocaleStringList(group, key, locale string, list []string) {
+	clocale := C.CString(locale)
+	defer C.free(unsafe.Pointer(clocale))
+	strv := cStrv(list)
+	defer freeStrv(strv, len(list))
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_locale_string_list(v.native(), g, k, (*C.gchar)(clocale),
+			strv, C.gsize(len(list)))
+	})
+}
+
+// GetBooleanList is a wrapper around g_key_file_get_boolean_list().
+func (v *KeyFile) GetBooleanList(group, key string) ([]bool, error) {
+	var list []bool
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		var length C.gsize
+		c := C.g_key_file_get_boolean_list(v.native(), g, k, &length, err)
+		defer C.g_free(C.gpointer(c))
+		list = make([]bool, length)
+		for i := range list {
+			list[i] = gobool(C.gboolean_list_get(c, C.gsize(i)))
+		}
+	})
+	return list, err
+}
+
+// SetBooleanList is a wrapper around g_key_file_set_boolean_list().
+func (v *KeyFile) SetBooleanList(group, key string, list []bool) {
+	clist := make([]C.gboolean, len(list)+1)
+	for i := range list {
+		clist[i] = gbool(list[i])
+	}
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_boolean_list(v.native(), g, k, &clist[0], C.gsize(len(list)))
+	})
+}
+
+// GetIntegerList is a wrapper around g_key_file_get_integer_list().
+func (v *KeyFile) GetIntegerList(group, key string) ([]int, error) {
+	var list []int
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		var length C.gsize
+		c := C.g_key_file_get_integer_list(v.native(), g, k, &length, err)
+		defer C.g_free(C.gpointer(c))
+		list = make([]int, length)
+		for i := range list {
+			list[i] = int(C.gint_list_get(c, C.gsize(i)))
+		}
+	})
+	return list, err
+}
+
+// SetIntegerList is a wrapper around g_key_file_set_integer_list().
+func (v *KeyFile) SetIntegerList(group, key string, list []int) {
+	clist := make([]C.gint, len(list)+1)
+	for i := range list {
+		clist[i] = C.gint(list[i])
+	}
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_integer_list(v.native(), g, k, &clist[0], C.gsize(len(list)))
+	})
+}
+
+// GetDoubleList is a wrapper around g_key_file_get_double_list().
+func (v *KeyFile) GetDoubleList(group, key string) ([]float64, error) {
+	var list []float64
+	err := keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		var length C.gsize
+		c := C.g_key_file_get_double_list(v.native(), g, k, &length, err)
+		defer C.g_free(C.gpointer(c))
+		list = make([]float64, length)
+		for i := range list {
+			list[i] = float64(C.gdouble_list_get(c, C.gsize(i)))
+		}
+	})
+	return list, err
+}
+
+// SetDoubleList is a wrapper around g_key_file_set_double_list().
+func (v *KeyFile) SetDoubleList(group, key string, list []float64) {
+	clist := make([]C.gdouble, len(list)+1)
+	for i := range list {
+		clist[i] = C.gdouble(list[i])
+	}
+	keyFileSet(group, key, func(g, k *C.gchar) {
+		C.g_key_file_set_double_list(v.native(), g, k, &clist[0], C.gsize(len(list)))
+	})
+}
+
+// GetComment is a wrapper around g_key_file_get_comment().  If key is
+// empty, the comment above group is returned, and if group is also
+// empty, the comment at the top of the file.
+func (v *KeyFile) GetComment(group, key string) (string, error) {
+	cgroup := optCString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := optCString(key)
+	defer C.free(unsafe.Pointer(ckey))
+
+	var err *C.GError
+	c := C.g_key_file_get_comment(v.native(), cgroup, ckey, &err)
+	if err != nil {
+		return "", TakeError(unsafe.Pointer(err))
+	}
+	return takeString(c), nil
+}
+
+// SetComment is a wrapper around g_key_file_set_comment().  group and key
+// select the comment as for GetComment.
+func (v *KeyFile) SetComment(group, key, comment string) error {
+	cgroup := optCString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := optCString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	ccomment := C.CString(comment)
+	defer C.free(unsafe.Pointer(ccomment))
+
+	var err *C.GError
+	C.g_key_file_set_comment(v.native(), cgroup, ckey, (*C.gchar)(ccomment), &err)
+	return TakeError(unsafe.Pointer(err))
+}
+
+// RemoveComment is a wrapper around g_key_file_remove_comment().  group
+// and key select the comment as for GetComment.
+func (v *KeyFile) RemoveComment(group, key string) error {
+	cgroup := optCString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+	ckey := optCString(key)
+	defer C.free(unsafe.Pointer(ckey))
+
+	var err *C.GError
+	C.g_key_file_remove_comment(v.native(), cgroup, ckey, &err)
+	return TakeError(unsafe.Pointer(err))
+}
+
+// RemoveKey is a wrapper around g_key_file_remove_key().
+func (v *KeyFile) RemoveKey(group, key string) error {
+	return keyFileGet(group, key, func(g, k *C.gchar, err **C.GError) {
+		C.g_key_file_remove_key(v.native(), g, k, err)
+	})
+}
+
+// RemoveGroup is a wrapper around g_key_file_remove_group().
+func (v *KeyFile) RemoveGroup(group string) error {
+	cgroup := C.CString(group)
+	defer C.free(unsafe.Pointer(cgroup))
+
+	var err *C.GError
+	C.g_key_file_remove_group(v.native(), (*C.gchar)(cgroup), &err)
+	return TakeError(unsafe.Pointer(err))
+}
@@ -35,17 +35,78 @@ func (v *List) Insert(data uintptr, position int) *List {
 	return (*List)(unsafe.Pointer(glist))
 }
 
+// Transfer describes the ownership of a list, or another container,
+// passed between Go and C, as in the transfer annotations of GLib's
+// documentation.
+type Transfer int
+
+const (
+	// TRANSFER_NONE means the container and its elements are owned by
+	// the callee and must not be freed.
+	TRANSFER_NONE Transfer = iota
+
+	// TRANSFER_CONTAINER means the caller owns the container, but not
+	// its elements.
+	TRANSFER_CONTAINER
+
+	// TRANSFER_FULL means the caller owns the container and its
+	// elements.
+	TRANSFER_FULL
+)
+
+// ListToSlice converts the elements of l to a slice, calling wrap with
+// the data of each element.  wrap never takes ownership of the data, so
+// it must take its own reference, or copy the data, if the result keeps
+// it.  l is freed with g_list_free() unless transfer is TRANSFER_NONE,
+// and with TRANSFER_FULL, free, if not nil, is also called with the data
+// of each element once all elements have been wrapped.
+func ListToSlice[T any](l *List, transfer Transfer, wrap func(data uintptr) T, free func(data uintptr)) []T {
+	var s []T
+	for e := l; e != nil; e = e.Next {
+		s = append(s, wrap(e.Data))
+	}
+	if transfer == TRANSFER_FULL && free != nil {
+		for e := l; e != nil; e = e.Next {
+			free(e.Data)
+		}
+	}
+	if transfer != TRANSFER_NONE {
+		l.Free()
+	}
+	return s
+}
+
+// ListFromSlice creates a new list holding the result of data for each
+// element of s, in order.  The list must be freed with Free, or passed to
+// a function taking ownership of it.
+func ListFromSlice[T any](s []T, data func(T) uintptr) *List {
+	var glist *C.GList
+	for i := len(s) - 1; i >= 0; i-- {
+		glist = C.g_list_prepend(glist, C.gpointer(data(s[i])))
+	}
+	return (*List)(unsafe.Pointer(glist))
+}
+
+// Free is a wrapper around g_list_free().  The data of the elements is
+// not freed.
+func (v *List) Free() {
+	C.g_list_free((*C.GList)(unsafe.Pointer(v)))
+}
+
+// Length is a wrapper around g_list_length().
+func (v *List) Length() uint {
+	return uint(C.g_list_length((*C.GList)(unsafe.Pointer(v))))
+}
+
 //GList *	g_list_insert_before ()
 //GList *	g_list_insert_sorted ()
 //GList *	g_list_remove ()
 //GList *	g_list_remove_link ()
 //GList *	g_list_delete_link ()
 //GList *	g_list_remove_all ()
-//void	g_list_free ()
 //void	g_list_free_full ()
 //GList *	g_list_alloc ()
 //void	g_list_free_1 ()
-//guint	g_list_length ()
 //GList *	g_list_copy ()
 //GList *	g_list_copy_deep ()
 //GList *	g_list_reverse ()
@@ -0,0 +1,310 @@
+//GLog : Message Logging — versatile support for logging messages with different levels of importance
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"context"
+	"log/slog"
+	"math/bits"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LogLevelFlags is a representation of GLib's GLogLevelFlags.
+type LogLevelFlags int
+
+const (
+	LOG_FLAG_RECURSION LogLevelFlags = C.G_LOG_FLAG_RECURSION
+	LOG_FLAG_FATAL     LogLevelFlags = C.G_LOG_FLAG_FATAL
+	LOG_LEVEL_ERROR    LogLevelFlags = C.G_LOG_LEVEL_ERROR
+	LOG_LEVEL_CRITICAL LogLevelFlags = C.G_LOG_LEVEL_CRITICAL
+	LOG_LEVEL_WARNING  LogLevelFlags = C.G_LOG_LEVEL_WARNING
+	LOG_LEVEL_MESSAGE  LogLevelFlags = C.G_LOG_LEVEL_MESSAGE
+	LOG_LEVEL_INFO     LogLevelFlags = C.G_LOG_LEVEL_INFO
+	LOG_LEVEL_DEBUG    LogLevelFlags = C.G_LOG_LEVEL_DEBUG
+	LOG_LEVEL_MASK     LogLevelFlags = C.G_LOG_LEVEL_MASK
+)
+
+// LogOptions holds the options of SetLogHandler.
+type LogOptions struct {
+	// FatalCriticals aborts the program once a CRITICAL message has
+	// been passed to the handler, as G_DEBUG=fatal-criticals does.  It
+	// is mostly useful in tests.
+	FatalCriticals bool
+}
+
+var (
+	logWriter = struct {
+		sync.RWMutex
+		installed bool
+		handler   slog.Handler
+		opts      LogOptions
+	}{}
+
+	// logCounts holds the number of messages written for each log
+	// level, from LOG_LEVEL_ERROR to LOG_LEVEL_DEBUG.
+	logCounts [6]uint64
+)
+
+// SetLogHandler routes the messages logged with g_log() and
+// g_log_structured() by GLib and libraries using it, such as GTK, to h.
+// The GLib log domain is passed as the "domain" attribute and other
+// structured fields are passed as attributes named by their key.  If h is
+// nil, messages are written by g_log_writer_default() again.  opts may be
+// nil to use the default options.
+//
+// GLib only allows its log writer to be set once, so the writer installed
+// by the first call stays in place for the lifetime of the program.
+// Messages must not be routed to a LogHandler, which would write them
+// back to GLib.
+func SetLogHandler(h slog.Handler, opts *LogOptions) {
+	logWriter.Lock()
+	defer logWriter.Unlock()
+
+	logWriter.handler = h
+	logWriter.opts = LogOptions{}
+	if opts != nil {
+		logWriter.opts = *opts
+	}
+	if !logWriter.installed {
+		C._g_log_set_writer_func()
+		logWriter.installed = true
+	}
+}
+
+// LogCount returns the number of messages of level, such as
+// LOG_LEVEL_WARNING, written since SetLogHandler was first called.
+// Messages are counted whether or not a handler is set.
+func LogCount(level LogLevelFlags) uint64 {
+	i, ok := logLevelIndex(level)
+	if !ok {
+		return 0
+	}
+	return atomic.LoadUint64(&logCounts[i])
+}
+
+// logLevelIndex returns the index in logCounts of the most severe level
+// set in level.
+func logLevelIndex(level LogLevelFlags) (int, bool) {
+	level &= LOG_LEVEL_MASK
+	if level == 0 {
+		return 0, false
+	}
+	i := bits.TrailingZeros(uint(level)) - bits.TrailingZeros(uint(LOG_LEVEL_ERROR))
+	if i < 0 || i >= len(logCounts) {
+		return 0, false
+	}
+	return i, true
+}
+
+// slogLevel returns the slog.Level corresponding to a GLib log level.
+func slogLevel(level LogLevelFlags) slog.Level {
+	switch {
+	case level&LOG_LEVEL_ERROR != 0:
+		return slog.LevelError + 4
+	case level&LOG_LEVEL_CRITICAL != 0:
+		return slog.LevelError
+	case level&LOG_LEVEL_WARNING != 0:
+		return slog.LevelWarn
+	case level&(LOG_LEVEL_MESSAGE|LOG_LEVEL_INFO) != 0:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
+
+//export goLogWriter
+func goLogWriter(level C.GLogLevelFlags, fields *C.GLogField, nFields C.gsize, fatal *C.gboolean) C.GLogWriterOutput {
+	lvl := LogLevelFlags(level)
+	if i, ok := logLevelIndex(lvl); ok {
+		atomic.AddUint64(&logCounts[i], 1)
+	}
+
+	logWriter.RLock()
+	h, opts := logWriter.handler, logWriter.opts
+	logWriter.RUnlock()
+
+	if opts.FatalCriticals && lvl&LOG_LEVEL_CRITICAL != 0 {
+		*fatal = gbool(true)
+	}
+	if h == nil {
+		return C.g_log_writer_default(level, fields, nFields, nil)
+	}
+
+	ctx := context.Background()
+	slevel := slogLevel(lvl)
+	if !h.Enabled(ctx, slevel) {
+		return C.G_LOG_WRITER_HANDLED
+	}
+
+	var msg string
+	var attrs []slog.Attr
+	for i := C.gsize(0); i < nFields; i++ {
+		f := C._g_log_field_get(fields, i)
+		key := C.GoString((*C.char)(f.key))
+		var value string
+		if f.length < 0 {
+			value = C.GoString((*C.char)(f.value))
+		} else {
+			value = C.GoStringN((*C.char)(f.value), C.int(f.length))
+		}
+
+		switch key {
+		case "MESSAGE":
+			msg = value
+		case "PRIORITY":
+		case "GLIB_DOMAIN":
+			attrs = append(attrs, slog.String("domain", value))
+		default:
+			attrs = append(attrs, slog.String(key, value))
+		}
+	}
+
+	r := slog.NewRecord(time.Now(), slevel, msg, 0)
+	r.AddAttrs(attrs...)
+	if err := h.Handle(ctx, r); err != nil {
+		return C.G_LOG_WRITER_UNHANDLED
+	}
+	return C.G_LOG_WRITER_HANDLED
+}
+
+/*
+ * slog.Handler
+ */
+
+// logField is a structured log field written by a LogHandler.
+type logField struct {
+	key, value string
+}
+
+// LogHandler is a slog.Handler which writes records to the GLib log
+// writer with g_log_structured_array(), so that messages from Go and C
+// code share the same destination, such as the systemd journal.
+//
+// Attribute keys are converted to the upper case field names GLib
+// expects, and the keys of attributes in groups are prefixed by the group
+// names.  Records of slog.LevelError and above are logged as
+// LOG_LEVEL_CRITICAL, never as the fatal LOG_LEVEL_ERROR.
+type LogHandler struct {
+	domain string
+	level  slog.Leveler
+	prefix string
+	fields []logField
+}
+
+// NewLogHandler returns a LogHandler which logs records of at least level
+// to the GLib log domain.  level may be nil to log records of
+// slog.LevelInfo and above.
+func NewLogHandler(domain string, level slog.Leveler) *LogHandler {
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &LogHandler{domain: domain, level: level}
+}
+
+// Enabled returns whether records of level are logged by h.
+func (h *LogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes r with g_log_structured_array().
+func (h *LogHandler) Handle(_ context.Context, r slog.Record) error {
+	level, priority := glibLogLevel(r.Level)
+	fields := []logField{
+		{"MESSAGE", r.Message},
+		{"PRIORITY", priority},
+	}
+	if h.domain != "" {
+		fields = append(fields, logField{"GLIB_DOMAIN", h.domain})
+	}
+	fields = append(fields, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendLogFields(fields, h.prefix, a)
+		return true
+	})
+
+	n := C.gsize(len(fields))
+	cfields := C._g_log_fields_new(n)
+	defer C._g_log_fields_free(cfields, n)
+	for i, f := range fields {
+		C._g_log_field_set(cfields, C.gsize(i), C.CString(f.key), C.CString(f.value))
+	}
+	C.g_log_structured_array(C.GLogLevelFlags(level), cfields, n)
+	return nil
+}
+
+// WithAttrs returns a LogHandler which also writes attrs with each record.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.fields = append([]logField(nil), h.fields...)
+	for _, a := range attrs {
+		h2.fields = appendLogFields(h2.fields, h.prefix, a)
+	}
+	return &h2
+}
+
+// WithGroup returns a LogHandler which prefixes the keys of attributes by
+// name.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+// glibLogLevel returns the GLib log level and syslog priority used for
+// records of level.
+func glibLogLevel(level slog.Level) (LogLevelFlags, string) {
+	switch {
+	case level >= slog.LevelError:
+		return LOG_LEVEL_CRITICAL, "4"
+	case level >= slog.LevelWarn:
+		return LOG_LEVEL_WARNING, "4"
+	case level >= slog.LevelInfo:
+		return LOG_LEVEL_MESSAGE, "5"
+	default:
+		return LOG_LEVEL_DEBUG, "7"
+	}
+}
+
+// appendLogFields appends the fields for the attribute a, with keys
+// prefixed by prefix, to fields.
+func appendLogFields(fields []logField, prefix string, a slog.Attr) []logField {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			fields = appendLogFields(fields, prefix, ga)
+		}
+		return fields
+	}
+	if a.Key == "" {
+		return fields
+	}
+	return append(fields, logField{logFieldKey(prefix + a.Key), v.String()})
+}
+
+// logFieldKey converts key to a valid structured log field name, which
+// only contains upper case letters, digits and underscores.
+func logFieldKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
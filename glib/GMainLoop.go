@@ -0,0 +1,302 @@
+//GMainLoop : The Main Event Loop — manages all available sources of events
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"unsafe"
+)
+
+/*
+ * GMainContext
+ */
+
+// MainContext is a representation of GLib's GMainContext.
+//
+// A MainContext may only be iterated by the thread which owns it, so
+// goroutines running a context with Iteration or a MainLoop should call
+// runtime.LockOSThread first.
+type MainContext struct {
+	GMainContext *C.GMainContext
+}
+
+// native returns a pointer to the underlying GMainContext.
+func (v *MainContext) native() *C.GMainContext {
+	if v == nil || v.GMainContext == nil {
+		return nil
+	}
+	return v.GMainContext
+}
+
+// Native returns a pointer to the underlying GMainContext.
+func (v *MainContext) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// takeMainContext wraps a GMainContext the caller owns a reference to and
+// sets a runtime finalizer to release it.
+func takeMainContext(p *C.GMainContext) *MainContext {
+	ctx := &MainContext{p}
+	runtime.SetFinalizer(ctx, (*MainContext).Unref)
+	return ctx
+}
+
+// MainContextNew is a wrapper around g_main_context_new().
+func MainContextNew() (*MainContext, error) {
+	c := C.g_main_context_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return takeMainContext(c), nil
+}
+
+// MainContextDefault is a wrapper around g_main_context_default().  The
+// default context is the one used by the GTK main loop, IdleAdd and
+// TimeoutAdd.
+func MainContextDefault() *MainContext {
+	return &MainContext{C.g_main_context_default()}
+}
+
+// MainContextThreadDefault is a wrapper around
+// g_main_context_ref_thread_default().  It returns the context pushed as
+// the thread default of the calling OS thread, or the global default
+// context if there is none.
+func MainContextThreadDefault() *MainContext {
+	return takeMainContext(C.g_main_context_ref_thread_default())
+}
+
+// Ref is a wrapper around g_main_context_ref().
+func (v *MainContext) Ref() {
+	C.g_main_context_ref(v.native())
+}
+
+// Unref is a wrapper around g_main_context_unref().
+func (v *MainContext) Unref() {
+	C.g_main_context_unref(v.native())
+}
+
+// PushThreadDefault is a wrapper around
+// g_main_context_push_thread_default().  The calling goroutine must be
+// locked to its OS thread until the matching PopThreadDefault.
+func (v *MainContext) PushThreadDefault() {
+	C.g_main_context_push_thread_default(v.native())
+}
+
+// PopThreadDefault is a wrapper around g_main_context_pop_thread_default().
+func (v *MainContext) PopThreadDefault() {
+	C.g_main_context_pop_thread_default(v.native())
+}
+
+// Iteration is a wrapper around g_main_context_iteration().  It returns
+// true if any events were dispatched.
+func (v *MainContext) Iteration(mayBlock bool) bool {
+	return gobool(C.g_main_context_iteration(v.native(), gbool(mayBlock)))
+}
+
+// Pending is a wrapper around g_main_context_pending().
+func (v *MainContext) Pending() bool {
+	return gobool(C.g_main_context_pending(v.native()))
+}
+
+// Wakeup is a wrapper around g_main_context_wakeup().
+func (v *MainContext) Wakeup() {
+	C.g_main_context_wakeup(v.native())
+}
+
+// Acquire is a wrapper around g_main_context_acquire().
+func (v *MainContext) Acquire() bool {
+	return gobool(C.g_main_context_acquire(v.native()))
+}
+
+// Release is a wrapper around g_main_context_release().
+func (v *MainContext) Release() {
+	C.g_main_context_release(v.native())
+}
+
+// IsOwner is a wrapper around g_main_context_is_owner().
+func (v *MainContext) IsOwner() bool {
+	return gobool(C.g_main_context_is_owner(v.native()))
+}
+
+// Invoke behaves like g_main_context_invoke().  If the calling thread owns
+// v, f is called immediately with args.  If v is the thread-default
+// context of the calling thread, or the global default context and no
+// thread-default context was pushed, f is also called immediately if v
+// can be acquired.  Otherwise f is added as an idle source to v and will
+// run the next time v is iterated, on the thread iterating it.  Unlike
+// IdleAdd, the return value of f is ignored and f is called only once.
+//
+// This function will cause a panic when f eventually runs if the
+// types of args do not match those of f.
+func (v *MainContext) Invoke(f interface{}, args ...interface{}) error {
+	rf := reflect.ValueOf(f)
+	if rf.Type().Kind() != reflect.Func {
+		return errors.New("f is not a function")
+	}
+
+	if v.invokeOwned(func() { callSourceFunc(rf, args) }) {
+		return nil
+	}
+
+	once := func() bool {
+		callSourceFunc(rf, args)
+		return false
+	}
+	_, err := v.IdleAdd(once)
+	return err
+}
+
+// invokeOwned calls f and returns true if the calling thread owns v, or
+// if v is the thread-default context of the calling thread and can be
+// acquired, as g_main_context_invoke() does.  It returns false without
+// calling f otherwise.
+func (v *MainContext) invokeOwned(f func()) bool {
+	// The thread-default context and the ownership of v belong to the OS
+	// thread, which must not change until v is released.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if v.IsOwner() {
+		f()
+		return true
+	}
+	td := C.g_main_context_get_thread_default()
+	if td == nil {
+		td = C.g_main_context_default()
+	}
+	if td != v.native() || !v.Acquire() {
+		return false
+	}
+	defer v.Release()
+	f()
+	return true
+}
+
+// callSourceFunc calls rf with args.
+func callSourceFunc(rf reflect.Value, args []interface{}) []reflect.Value {
+	rargs := make([]reflect.Value, len(args))
+	for i := range args {
+		rargs[i] = reflect.ValueOf(args[i])
+	}
+	return rf.Call(rargs)
+}
+
+// IdleAdd adds an idle source to the main event loop context v.  It
+// behaves like the package-level IdleAdd, which uses the default
+// context.
+func (v *MainContext) IdleAdd(f interface{}, args ...interface{}) (SourceHandle, error) {
+	rf := reflect.ValueOf(f)
+	if rf.Type().Kind() != reflect.Func {
+		return 0, errors.New("f is not a function")
+	}
+
+	idleSrc := C.g_idle_source_new()
+	if idleSrc == nil {
+		return 0, errNilPtr
+	}
+	return sourceAttach(idleSrc, v.native(), rf, args...)
+}
+
+// TimeoutAdd adds a timeout source to the main event loop context v.  It
+// behaves like the package-level TimeoutAdd, which uses the default
+// context.  timeout is in milliseconds.
+func (v *MainContext) TimeoutAdd(timeout uint, f interface{}, args ...interface{}) (SourceHandle, error) {
+	rf := reflect.ValueOf(f)
+	if rf.Type().Kind() != reflect.Func {
+		return 0, errors.New("f is not a function")
+	}
+
+	timeoutSrc := C.g_timeout_source_new(C.guint(timeout))
+	if timeoutSrc == nil {
+		return 0, errNilPtr
+	}
+	return sourceAttach(timeoutSrc, v.native(), rf, args...)
+}
+
+// SourceRemove removes a source added to v.  It returns false if no such
+// source exists, for example because it has already run for the last time.
+func (v *MainContext) SourceRemove(handle SourceHandle) bool {
+	src := C.g_main_context_find_source_by_id(v.native(), C.guint(handle))
+	if src == nil {
+		return false
+	}
+	C.g_source_destroy(src)
+	return true
+}
+
+/*
+ * GMainLoop
+ */
+
+// MainLoop is a representation of GLib's GMainLoop.
+type MainLoop struct {
+	GMainLoop *C.GMainLoop
+}
+
+// native returns a pointer to the underlying GMainLoop.
+func (v *MainLoop) native() *C.GMainLoop {
+	if v == nil || v.GMainLoop == nil {
+		return nil
+	}
+	return v.GMainLoop
+}
+
+// Native returns a pointer to the underlying GMainLoop.
+func (v *MainLoop) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// MainLoopNew is a wrapper around g_main_loop_new().  ctx may be nil to
+// use the global default context.  A runtime finalizer is set to release
+// the loop.
+func MainLoopNew(ctx *MainContext, isRunning bool) (*MainLoop, error) {
+	c := C.g_main_loop_new(ctx.native(), gbool(isRunning))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	loop := &MainLoop{c}
+	runtime.SetFinalizer(loop, (*MainLoop).Unref)
+	return loop, nil
+}
+
+// Ref is a wrapper around g_main_loop_ref().
+func (v *MainLoop) Ref() {
+	C.g_main_loop_ref(v.native())
+}
+
+// Unref is a wrapper around g_main_loop_unref().
+func (v *MainLoop) Unref() {
+	C.g_main_loop_unref(v.native())
+}
+
+// Run is a wrapper around g_main_loop_run().  Run blocks until Quit is
+// called.  Because the loop's context is owned by the OS thread running
+// it, the calling goroutine should be locked to its thread with
+// runtime.LockOSThread.
+func (v *MainLoop) Run() {
+	C.g_main_loop_run(v.native())
+}
+
+// Quit is a wrapper around g_main_loop_quit().  It may be called from any
+// goroutine.
+func (v *MainLoop) Quit() {
+	C.g_main_loop_quit(v.native())
+}
+
+// IsRunning is a wrapper around g_main_loop_is_running().
+func (v *MainLoop) IsRunning() bool {
+	return gobool(C.g_main_loop_is_running(v.native()))
+}
+
+// GetContext is a wrapper around g_main_loop_get_context().
+func (v *MainLoop) GetContext() *MainContext {
+	c := C.g_main_loop_get_context(v.native())
+	C.g_main_context_ref(c)
+	return takeMainContext(c)
+}
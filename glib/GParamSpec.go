@@ -0,0 +1,309 @@
+//GParamSpec : GParamSpec — Metadata for parameter specifications
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+)
+
+// ParamFlags is a representation of GLib's GParamFlags.
+type ParamFlags int
+
+const (
+	PARAM_READABLE       ParamFlags = C.G_PARAM_READABLE
+	PARAM_WRITABLE       ParamFlags = C.G_PARAM_WRITABLE
+	PARAM_READWRITE      ParamFlags = C.G_PARAM_READWRITE
+	PARAM_CONSTRUCT      ParamFlags = C.G_PARAM_CONSTRUCT
+	PARAM_CONSTRUCT_ONLY ParamFlags = C.G_PARAM_CONSTRUCT_ONLY
+	PARAM_LAX_VALIDATION ParamFlags = C.G_PARAM_LAX_VALIDATION
+	PARAM_STATIC_NAME    ParamFlags = C.G_PARAM_STATIC_NAME
+	PARAM_STATIC_NICK    ParamFlags = C.G_PARAM_STATIC_NICK
+	PARAM_STATIC_BLURB   ParamFlags = C.G_PARAM_STATIC_BLURB
+	PARAM_STATIC_STRINGS ParamFlags = C.G_PARAM_STATIC_STRINGS
+	PARAM_DEPRECATED     ParamFlags = C.G_PARAM_DEPRECATED
+)
+
+/*
+ * GParamSpec
+ */
+
+// ParamSpec is a representation of GLib's GParamSpec.
+type ParamSpec struct {
+	GParamSpec *C.GParamSpec
+}
+
+// native returns a pointer to the underlying GParamSpec.
+func (v *ParamSpec) native() *C.GParamSpec {
+	if v == nil || v.GParamSpec == nil {
+		return nil
+	}
+	return v.GParamSpec
+}
+
+// Native returns a pointer to the underlying GParamSpec.
+func (v *ParamSpec) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// refParamSpec wraps a GParamSpec owned by someone else, taking a new
+// reference which is released by a runtime finalizer.  refParamSpec
+// returns nil if p is nil.
+func refParamSpec(p *C.GParamSpec) *ParamSpec {
+	if p == nil {
+		return nil
+	}
+	C.g_param_spec_ref_sink(p)
+	pspec := &ParamSpec{p}
+	runtime.SetFinalizer(pspec, (*ParamSpec).Unref)
+	return pspec
+}
+
+// newParamSpec wraps a GParamSpec returned by one of the g_param_spec_*()
+// constructors.
+func newParamSpec(p *C.GParamSpec) (*ParamSpec, error) {
+	if p == nil {
+		return nil, errNilPtr
+	}
+	return refParamSpec(p), nil
+}
+
+// paramSpecStrings returns C copies of the name, nick and blurb of a new
+// GParamSpec, which must be freed with freeParamSpecStrings.
+func paramSpecStrings(name, nick, blurb string) (cname, cnick, cblurb *C.gchar) {
+	return (*C.gchar)(C.CString(name)), (*C.gchar)(C.CString(nick)),
+		(*C.gchar)(C.CString(blurb))
+}
+
+func freeParamSpecStrings(cname, cnick, cblurb *C.gchar) {
+	C.free(unsafe.Pointer(cname))
+	C.free(unsafe.Pointer(cnick))
+	C.free(unsafe.Pointer(cblurb))
+}
+
+// ParamSpecBoolean is a wrapper around g_param_spec_boolean().
+func ParamSpecBoolean(name, nick, blurb string, defaultValue bool, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_boolean(cname, cnick, cblurb, gbool(defaultValue),
+		C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecInt is a wrapper around g_param_spec_int().
+func ParamSpecInt(name, nick, blurb string, min, max, defaultValue int, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_int(cname, cnick, cblurb, C.gint(min), C.gint(max),
+		C.gint(defaultValue), C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecUint is a wrapper around g_param_spec_uint().
+func ParamSpecUint(name, nick, blurb string, min, max, defaultValue uint, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_uint(cname, cnick, cblurb, C.guint(min), C.guint(max),
+		C.guint(defaultValue), C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecInt64 is a wrapper around g_param_spec_int64().
+func ParamSpecInt64(name, nick, blurb string, min, max, defaultValue int64, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_int64(cname, cnick, cblurb, C.gint64(min),
+		C.gint64(max), C.gint64(defaultValue), C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecUint64 is a wrapper around g_param_spec_uint64().
+func ParamSpecUint64(name, nick, blurb string, min, max, defaultValue uint64, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_uint64(cname, cnick, cblurb, C.guint64(min),
+		C.guint64(max), C.guint64(defaultValue), C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecFloat is a wrapper around g_param_spec_float().
+func ParamSpecFloat(name, nick, blurb string, min, max, defaultValue float32, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_float(cname, cnick, cblurb, C.gfloat(min),
+		C.gfloat(max), C.gfloat(defaultValue), C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecDouble is a wrapper around g_param_spec_double().
+func ParamSpecDouble(name, nick, blurb string, min, max, defaultValue float64, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_double(cname, cnick, cblurb, C.gdouble(min),
+		C.gdouble(max), C.gdouble(defaultValue), C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecEnum is a wrapper around g_param_spec_enum().
+func ParamSpecEnum(name, nick, blurb string, enumType Type, defaultValue int, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_enum(cname, cnick, cblurb, C.GType(enumType),
+		C.gint(defaultValue), C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecFlags is a wrapper around g_param_spec_flags().
+func ParamSpecFlags(name, nick, blurb string, flagsType Type, defaultValue uint, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_flags(cname, cnick, cblurb, C.GType(flagsType),
+		C.guint(defaultValue), C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecString is a wrapper around g_param_spec_string().
+func ParamSpecString(name, nick, blurb string, defaultValue string, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	cdefault := C.CString(defaultValue)
+	defer C.free(unsafe.Pointer(cdefault))
+	c := C.g_param_spec_string(cname, cnick, cblurb, (*C.gchar)(cdefault),
+		C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// ParamSpecObject is a wrapper around g_param_spec_object().  objectType
+// must be TYPE_OBJECT or a subtype of it.
+func ParamSpecObject(name, nick, blurb string, objectType Type, flags ParamFlags) (*ParamSpec, error) {
+	cname, cnick, cblurb := paramSpecStrings(name, nick, blurb)
+	defer freeParamSpecStrings(cname, cnick, cblurb)
+	c := C.g_param_spec_object(cname, cnick, cblurb, C.GType(objectType),
+		C.GParamFlags(flags))
+	return newParamSpec(c)
+}
+
+// Ref is a wrapper around g_param_spec_ref().
+func (v *ParamSpec) Ref() {
+	C.g_param_spec_ref(v.native())
+}
+
+// Unref is a wrapper around g_param_spec_unref().
+func (v *ParamSpec) Unref() {
+	C.g_param_spec_unref(v.native())
+}
+
+// Name is a wrapper around g_param_spec_get_name().
+func (v *ParamSpec) Name() string {
+	return C.GoString((*C.char)(C.g_param_spec_get_name(v.native())))
+}
+
+// Nick is a wrapper around g_param_spec_get_nick().
+func (v *ParamSpec) Nick() string {
+	return C.GoString((*C.char)(C.g_param_spec_get_nick(v.native())))
+}
+
+// Blurb is a wrapper around g_param_spec_get_blurb().
+func (v *ParamSpec) Blurb() string {
+	return C.GoString((*C.char)(C.g_param_spec_get_blurb(v.native())))
+}
+
+// Flags returns the flags the GParamSpec was created with.
+func (v *ParamSpec) Flags() ParamFlags {
+	return ParamFlags(v.native().flags)
+}
+
+// ValueType returns the Type of the values described by the GParamSpec.
+func (v *ParamSpec) ValueType() Type {
+	return Type(v.native().value_type)
+}
+
+// OwnerType returns the Type of the class or interface which installed
+// the GParamSpec.
+func (v *ParamSpec) OwnerType() Type {
+	return Type(v.native().owner_type)
+}
+
+// IsReadable returns whether the PARAM_READABLE flag is set.
+func (v *ParamSpec) IsReadable() bool {
+	return v.Flags()&PARAM_READABLE != 0
+}
+
+// IsWritable returns whether the PARAM_WRITABLE flag is set.
+func (v *ParamSpec) IsWritable() bool {
+	return v.Flags()&PARAM_WRITABLE != 0
+}
+
+// DefaultValue uses g_param_value_set_default() to return the default
+// value of the GParamSpec as the same Go type GoValue would return.
+func (v *ParamSpec) DefaultValue() (interface{}, error) {
+	val, err := ValueInit(v.ValueType())
+	if err != nil {
+		return nil, err
+	}
+	C.g_param_value_set_default(v.native(), val.native())
+	return val.GoValue()
+}
+
+// Range returns the minimum and maximum values allowed by a numeric
+// GParamSpec, as the same Go type GoValue would return.  A non-nil error
+// is returned if the GParamSpec does not describe a numeric value.
+func (v *ParamSpec) Range() (min, max interface{}, err error) {
+	cmin, err := ValueAlloc()
+	if err != nil {
+		return nil, nil, err
+	}
+	cmax, err := ValueAlloc()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !gobool(C._g_param_spec_range(v.native(), cmin.native(), cmax.native())) {
+		return nil, nil, errors.New("param spec " + v.Name() + " has no range")
+	}
+	if min, err = cmin.GoValue(); err != nil {
+		return nil, nil, err
+	}
+	if max, err = cmax.GoValue(); err != nil {
+		return nil, nil, err
+	}
+	return min, max, nil
+}
+
+func marshalParamSpec(p uintptr) (interface{}, error) {
+	c := C.g_value_get_param((*C.GValue)(unsafe.Pointer(p)))
+	return refParamSpec(c), nil
+}
+
+/*
+ * GObject properties
+ */
+
+// FindProperty is a wrapper around g_object_class_find_property().  nil
+// is returned if the class of v has no property called name.
+func (v *Object) FindProperty(name string) *ParamSpec {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	class := C._g_object_get_class(v.native())
+	return refParamSpec(C.g_object_class_find_property(class, (*C.gchar)(cstr)))
+}
+
+// ListProperties is a wrapper around g_object_class_list_properties() and
+// returns the GParamSpecs of all properties of the class of v.
+func (v *Object) ListProperties() []*ParamSpec {
+	var n C.guint
+	class := C._g_object_get_class(v.native())
+	list := C.g_object_class_list_properties(class, &n)
+	defer C.g_free(C.gpointer(list))
+
+	pspecs := make([]*ParamSpec, n)
+	for i := range pspecs {
+		pspecs[i] = refParamSpec(C.pspec_list_get(list, C.guint(i)))
+	}
+	return pspecs
+}
@@ -0,0 +1,118 @@
+//GPtrArray : Pointer Arrays — arrays of pointers to any type of data, which grow automatically as new elements are added
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import "unsafe"
+
+// PtrArray is a representation of GLib's GPtrArray.  As with List, its
+// elements are raw pointers and its memory is not managed by the garbage
+// collector: arrays created by PtrArrayNew must be released with Unref.
+type PtrArray C.GPtrArray
+
+// native returns a pointer to the underlying GPtrArray.
+func (v *PtrArray) native() *C.GPtrArray {
+	return (*C.GPtrArray)(unsafe.Pointer(v))
+}
+
+// Native returns a pointer to the underlying GPtrArray.
+func (v *PtrArray) Native() uintptr {
+	return uintptr(unsafe.Pointer(v))
+}
+
+// PtrArrayNew is a wrapper around g_ptr_array_new().
+func PtrArrayNew() (*PtrArray, error) {
+	c := C.g_ptr_array_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return (*PtrArray)(unsafe.Pointer(c)), nil
+}
+
+// Ref is a wrapper around g_ptr_array_ref().
+func (v *PtrArray) Ref() *PtrArray {
+	return (*PtrArray)(unsafe.Pointer(C.g_ptr_array_ref(v.native())))
+}
+
+// Unref is a wrapper around g_ptr_array_unref().
+func (v *PtrArray) Unref() {
+	C.g_ptr_array_unref(v.native())
+}
+
+// Len returns the number of elements of the array.
+func (v *PtrArray) Len() uint {
+	if v == nil {
+		return 0
+	}
+	return uint(v.native().len)
+}
+
+// Index is a wrapper around g_ptr_array_index().  It panics if i is out
+// of range.
+func (v *PtrArray) Index(i uint) uintptr {
+	if i >= v.Len() {
+		panic("glib: PtrArray index out of range")
+	}
+	return uintptr(C._g_ptr_array_index(v.native(), C.guint(i)))
+}
+
+// Add is a wrapper around g_ptr_array_add().
+func (v *PtrArray) Add(data uintptr) {
+	C.g_ptr_array_add(v.native(), C.gpointer(data))
+}
+
+// Remove is a wrapper around g_ptr_array_remove().
+func (v *PtrArray) Remove(data uintptr) bool {
+	return gobool(C.g_ptr_array_remove(v.native(), C.gpointer(data)))
+}
+
+// RemoveIndex is a wrapper around g_ptr_array_remove_index().  It panics
+// if i is out of range.
+func (v *PtrArray) RemoveIndex(i uint) uintptr {
+	if i >= v.Len() {
+		panic("glib: PtrArray index out of range")
+	}
+	return uintptr(C.g_ptr_array_remove_index(v.native(), C.guint(i)))
+}
+
+// PtrArrayToSlice converts the elements of a to a slice, calling wrap
+// with each element.  As with ListToSlice, wrap never takes ownership of
+// the data.
+//
+// As with HashTableToMap, the elements are freed by the array's own free
+// function, if it has one.  With TRANSFER_FULL, the caller's reference to
+// a is released with g_ptr_array_unref().  With TRANSFER_CONTAINER, the
+// free function of a is first cleared so that the elements are not freed.
+// a is left alone with TRANSFER_NONE.
+func PtrArrayToSlice[T any](a *PtrArray, transfer Transfer, wrap func(data uintptr) T) []T {
+	n := a.Len()
+	s := make([]T, 0, n)
+	for i := uint(0); i < n; i++ {
+		s = append(s, wrap(a.Index(i)))
+	}
+	if a == nil {
+		return s
+	}
+	switch transfer {
+	case TRANSFER_CONTAINER:
+		C.g_ptr_array_set_free_func(a.native(), nil)
+		a.Unref()
+	case TRANSFER_FULL:
+		a.Unref()
+	}
+	return s
+}
+
+// PtrArrayFromSlice creates a new array holding the result of data for
+// each element of s, in order.  The array must be released with Unref, or
+// passed to a function taking ownership of it.
+func PtrArrayFromSlice[T any](s []T, data func(T) uintptr) *PtrArray {
+	c := C.g_ptr_array_sized_new(C.guint(len(s)))
+	for _, e := range s {
+		C.g_ptr_array_add(c, C.gpointer(data(e)))
+	}
+	return (*PtrArray)(unsafe.Pointer(c))
+}
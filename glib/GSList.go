@@ -0,0 +1,67 @@
+//GSList : Singly-Linked Lists — linked lists that can be iterated in one direction
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import "unsafe"
+
+// SList is a representation of Glib's GSList.
+type SList struct {
+	Data uintptr
+	Next *SList
+}
+
+// Append is a wrapper around g_slist_append().
+func (v *SList) Append(data uintptr) *SList {
+	gslist := (*C.GSList)(unsafe.Pointer(v))
+	gslist = C.g_slist_append(gslist, C.gpointer(data))
+	return (*SList)(unsafe.Pointer(gslist))
+}
+
+// Prepend is a wrapper around g_slist_prepend().
+func (v *SList) Prepend(data uintptr) *SList {
+	gslist := (*C.GSList)(unsafe.Pointer(v))
+	gslist = C.g_slist_prepend(gslist, C.gpointer(data))
+	return (*SList)(unsafe.Pointer(gslist))
+}
+
+// Free is a wrapper around g_slist_free().  The data of the elements is
+// not freed.
+func (v *SList) Free() {
+	C.g_slist_free((*C.GSList)(unsafe.Pointer(v)))
+}
+
+// Length is a wrapper around g_slist_length().
+func (v *SList) Length() uint {
+	return uint(C.g_slist_length((*C.GSList)(unsafe.Pointer(v))))
+}
+
+// SListToSlice behaves like ListToSlice for the singly-linked list l.
+func SListToSlice[T any](l *SList, transfer Transfer, wrap func(data uintptr) T, free func(data uintptr)) []T {
+	var s []T
+	for e := l; e != nil; e = e.Next {
+		s = append(s, wrap(e.Data))
+	}
+	if transfer == TRANSFER_FULL && free != nil {
+		for e := l; e != nil; e = e.Next {
+			free(e.Data)
+		}
+	}
+	if transfer != TRANSFER_NONE {
+		l.Free()
+	}
+	return s
+}
+
+// SListFromSlice behaves like ListFromSlice, but creates a singly-linked
+// list.
+func SListFromSlice[T any](s []T, data func(T) uintptr) *SList {
+	var gslist *C.GSList
+	for i := len(s) - 1; i >= 0; i-- {
+		gslist = C.g_slist_prepend(gslist, C.gpointer(data(s[i])))
+	}
+	return (*SList)(unsafe.Pointer(gslist))
+}
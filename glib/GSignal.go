@@ -0,0 +1,163 @@
+//GSignal : Signals — A means for customization of object behaviour and a general purpose notification mechanism
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// SignalFlags is a representation of GLib's GSignalFlags.
+type SignalFlags int
+
+const (
+	SIGNAL_RUN_FIRST    SignalFlags = C.G_SIGNAL_RUN_FIRST
+	SIGNAL_RUN_LAST     SignalFlags = C.G_SIGNAL_RUN_LAST
+	SIGNAL_RUN_CLEANUP  SignalFlags = C.G_SIGNAL_RUN_CLEANUP
+	SIGNAL_NO_RECURSE   SignalFlags = C.G_SIGNAL_NO_RECURSE
+	SIGNAL_DETAILED     SignalFlags = C.G_SIGNAL_DETAILED
+	SIGNAL_ACTION       SignalFlags = C.G_SIGNAL_ACTION
+	SIGNAL_NO_HOOKS     SignalFlags = C.G_SIGNAL_NO_HOOKS
+	SIGNAL_MUST_COLLECT SignalFlags = C.G_SIGNAL_MUST_COLLECT
+	SIGNAL_DEPRECATED   SignalFlags = C.G_SIGNAL_DEPRECATED
+)
+
+// SignalAccumulator selects how the return values of the handlers of a
+// signal are combined into the result of the emission.
+type SignalAccumulator int
+
+const (
+	// SIGNAL_ACCUMULATOR_NONE returns the value of the last handler run.
+	SIGNAL_ACCUMULATOR_NONE SignalAccumulator = C.GOTK3_SIGNAL_ACCUMULATOR_NONE
+
+	// SIGNAL_ACCUMULATOR_TRUE_HANDLED uses
+	// g_signal_accumulator_true_handled() to stop the emission as soon
+	// as a handler of a boolean signal returns true.
+	SIGNAL_ACCUMULATOR_TRUE_HANDLED SignalAccumulator = C.GOTK3_SIGNAL_ACCUMULATOR_TRUE_HANDLED
+
+	// SIGNAL_ACCUMULATOR_FIRST_WINS uses
+	// g_signal_accumulator_first_wins() to stop the emission after the
+	// first handler and return its value.
+	SIGNAL_ACCUMULATOR_FIRST_WINS SignalAccumulator = C.GOTK3_SIGNAL_ACCUMULATOR_FIRST_WINS
+)
+
+// SignalSpec describes a new signal, such as those added to a type
+// registered with RegisterType.  A ReturnType of TYPE_INVALID is treated
+// as TYPE_NONE.
+type SignalSpec struct {
+	Name        string
+	Flags       SignalFlags
+	Accumulator SignalAccumulator
+	ReturnType  Type
+	ParamTypes  []Type
+}
+
+// SignalNew is a wrapper around g_signal_newv() and creates a new signal
+// without an accumulator for ownerType.  It returns the id of the new
+// signal.
+func SignalNew(name string, ownerType Type, flags SignalFlags, returnType Type, paramTypes ...Type) (uint, error) {
+	return SignalNewFromSpec(ownerType, SignalSpec{
+		Name:       name,
+		Flags:      flags,
+		ReturnType: returnType,
+		ParamTypes: paramTypes,
+	})
+}
+
+// SignalNewFromSpec creates the signal described by spec for ownerType
+// and returns its id.
+func SignalNewFromSpec(ownerType Type, spec SignalSpec) (uint, error) {
+	cstr := C.CString(spec.Name)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var params *C.GType
+	if len(spec.ParamTypes) > 0 {
+		ctypes := make([]C.GType, len(spec.ParamTypes))
+		for i := range spec.ParamTypes {
+			ctypes[i] = C.GType(spec.ParamTypes[i])
+		}
+		params = &ctypes[0]
+	}
+
+	returnType := spec.ReturnType
+	if returnType == TYPE_INVALID {
+		returnType = TYPE_NONE
+	}
+	if spec.Accumulator == SIGNAL_ACCUMULATOR_TRUE_HANDLED && returnType != TYPE_BOOLEAN {
+		return 0, errors.New("signal " + spec.Name + " must return a boolean to use a true handled accumulator")
+	}
+
+	id := C._g_signal_newv((*C.gchar)(cstr), C.GType(ownerType),
+		C.GSignalFlags(spec.Flags), C.int(spec.Accumulator),
+		C.GType(returnType), C.guint(len(spec.ParamTypes)), params)
+	if id == 0 {
+		return 0, errors.New("unable to create signal " + spec.Name)
+	}
+	return uint(id), nil
+}
+
+// SignalLookup is a wrapper around g_signal_lookup().  A non-nil error is
+// returned if itype and its ancestors have no signal called name.
+func SignalLookup(name string, itype Type) (uint, error) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	id := C.g_signal_lookup((*C.gchar)(cstr), C.GType(itype))
+	if id == 0 {
+		return 0, errors.New("no signal " + name + " for type " + itype.Name())
+	}
+	return uint(id), nil
+}
+
+// SignalInfo holds the information about a signal returned by
+// SignalQuery.
+type SignalInfo struct {
+	ID         uint
+	Name       string
+	OwnerType  Type
+	Flags      SignalFlags
+	ReturnType Type
+	ParamTypes []Type
+}
+
+// SignalQuery is a wrapper around g_signal_query().  A non-nil error is
+// returned if id is not a valid signal id.
+func SignalQuery(id uint) (*SignalInfo, error) {
+	var q C.GSignalQuery
+	C.g_signal_query(C.guint(id), &q)
+	if q.signal_id == 0 {
+		return nil, errors.New("invalid signal id")
+	}
+
+	info := &SignalInfo{
+		ID:         uint(q.signal_id),
+		Name:       C.GoString((*C.char)(q.signal_name)),
+		OwnerType:  Type(q.itype),
+		Flags:      SignalFlags(q.signal_flags),
+		ReturnType: Type(q.return_type &^ C.GType(C.G_SIGNAL_TYPE_STATIC_SCOPE)),
+		ParamTypes: make([]Type, q.n_params),
+	}
+	for i := range info.ParamTypes {
+		t := C.gtype_list_get(q.param_types, C.guint(i))
+		info.ParamTypes[i] = Type(t &^ C.GType(C.G_SIGNAL_TYPE_STATIC_SCOPE))
+	}
+	return info, nil
+}
+
+// ListSignalIDs is a wrapper around g_signal_list_ids() and returns the
+// ids of the signals created for itype, not including those of its
+// ancestors.
+func ListSignalIDs(itype Type) []uint {
+	var n C.guint
+	c := C.g_signal_list_ids(C.GType(itype), &n)
+	defer C.g_free(C.gpointer(c))
+
+	ids := make([]uint, n)
+	for i := range ids {
+		ids[i] = uint(C.guint_list_get(c, C.guint(i)))
+	}
+	return ids
+}
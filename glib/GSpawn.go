@@ -0,0 +1,146 @@
+//GSpawn : Spawning Processes — process launching
+//go:build !windows
+// +build !windows
+
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import "unsafe"
+
+// Pid is a representation of GLib's GPid, which is a process ID on UNIX.
+type Pid int
+
+// SpawnFlags is a representation of GLib's GSpawnFlags.
+type SpawnFlags int
+
+const (
+	SPAWN_DEFAULT                SpawnFlags = C.G_SPAWN_DEFAULT
+	SPAWN_LEAVE_DESCRIPTORS_OPEN SpawnFlags = C.G_SPAWN_LEAVE_DESCRIPTORS_OPEN
+	SPAWN_DO_NOT_REAP_CHILD      SpawnFlags = C.G_SPAWN_DO_NOT_REAP_CHILD
+	SPAWN_SEARCH_PATH            SpawnFlags = C.G_SPAWN_SEARCH_PATH
+	SPAWN_STDOUT_TO_DEV_NULL     SpawnFlags = C.G_SPAWN_STDOUT_TO_DEV_NULL
+	SPAWN_STDERR_TO_DEV_NULL     SpawnFlags = C.G_SPAWN_STDERR_TO_DEV_NULL
+	SPAWN_CHILD_INHERITS_STDIN   SpawnFlags = C.G_SPAWN_CHILD_INHERITS_STDIN
+	SPAWN_FILE_AND_ARGV_ZERO     SpawnFlags = C.G_SPAWN_FILE_AND_ARGV_ZERO
+	SPAWN_SEARCH_PATH_FROM_ENVP  SpawnFlags = C.G_SPAWN_SEARCH_PATH_FROM_ENVP
+)
+
+// Sentinel errors of the G_SPAWN_ERROR domain.
+var (
+	ErrSpawnFork   = ErrorDomain(uint32(C.g_spawn_error_quark()), C.G_SPAWN_ERROR_FORK)
+	ErrSpawnChdir  = ErrorDomain(uint32(C.g_spawn_error_quark()), C.G_SPAWN_ERROR_CHDIR)
+	ErrSpawnAccess = ErrorDomain(uint32(C.g_spawn_error_quark()), C.G_SPAWN_ERROR_ACCES)
+	ErrSpawnNoExec = ErrorDomain(uint32(C.g_spawn_error_quark()), C.G_SPAWN_ERROR_NOEXEC)
+	ErrSpawnNoEnt  = ErrorDomain(uint32(C.g_spawn_error_quark()), C.G_SPAWN_ERROR_NOENT)
+	ErrSpawnInval  = ErrorDomain(uint32(C.g_spawn_error_quark()), C.G_SPAWN_ERROR_INVAL)
+	ErrSpawnFailed = ErrorDomain(uint32(C.g_spawn_error_quark()), C.G_SPAWN_ERROR_FAILED)
+)
+
+// SpawnAsyncWithPipes is a wrapper around g_spawn_async_with_pipes().  It
+// runs argv in workingDir, or in the current directory if workingDir is
+// empty, with the environment envp, or the environment of the parent if
+// envp is nil.
+//
+// Pipes are created for the standard input, output and error of the
+// child, except for those redirected by SPAWN_CHILD_INHERITS_STDIN,
+// SPAWN_STDOUT_TO_DEV_NULL and SPAWN_STDERR_TO_DEV_NULL, for which -1 is
+// returned.  The caller owns the returned descriptors, which may be read
+// on the main loop with IOChannelNewUnix and IOAddWatch, and must close
+// them.  SPAWN_DO_NOT_REAP_CHILD must be set to use ChildWatchAdd.
+func SpawnAsyncWithPipes(workingDir string, argv, envp []string, flags SpawnFlags) (pid Pid, stdin, stdout, stderr int, err error) {
+	cdir := optCString(workingDir)
+	defer C.free(unsafe.Pointer(cdir))
+	cargv := cStrv(argv)
+	defer freeStrv(cargv, len(argv))
+	var cenvp **C.gchar
+	if envp != nil {
+		cenvp = cStrv(envp)
+		defer freeStrv(cenvp, len(envp))
+	}
+
+	var cpid C.GPid
+	cin, cout, cerr := C.gint(-1), C.gint(-1), C.gint(-1)
+	var pin, pout, perr *C.gint
+	if flags&SPAWN_CHILD_INHERITS_STDIN == 0 {
+		pin = &cin
+	}
+	if flags&SPAWN_STDOUT_TO_DEV_NULL == 0 {
+		pout = &cout
+	}
+	if flags&SPAWN_STDERR_TO_DEV_NULL == 0 {
+		perr = &cerr
+	}
+
+	var gerr *C.GError
+	c := C.g_spawn_async_with_pipes(cdir, cargv, cenvp, C.GSpawnFlags(flags),
+		nil, nil, &cpid, pin, pout, perr, &gerr)
+	if !gobool(c) {
+		return 0, -1, -1, -1, TakeError(unsafe.Pointer(gerr))
+	}
+	return Pid(cpid), int(cin), int(cout), int(cerr), nil
+}
+
+// SpawnCommandLineSync is a wrapper around g_spawn_command_line_sync().
+// It parses commandLine with g_shell_parse_argv(), runs it, searching the
+// PATH, and waits for it to exit.  The standard output and error of the
+// child are returned up to their first nul byte, along with the wait
+// status, which may be checked with SpawnCheckExitStatus.
+func SpawnCommandLineSync(commandLine string) (stdout, stderr string, status int, err error) {
+	cstr := C.CString(commandLine)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var cout, cerr *C.gchar
+	var cstatus C.gint
+	var gerr *C.GError
+	c := C.g_spawn_command_line_sync((*C.gchar)(cstr), &cout, &cerr, &cstatus, &gerr)
+	if !gobool(c) {
+		return "", "", 0, TakeError(unsafe.Pointer(gerr))
+	}
+	return takeString(cout), takeString(cerr), int(cstatus), nil
+}
+
+// SpawnCommandLineAsync is a wrapper around g_spawn_command_line_async().
+// It parses commandLine with g_shell_parse_argv() and runs it, searching
+// the PATH, without waiting for it.
+func SpawnCommandLineAsync(commandLine string) error {
+	cstr := C.CString(commandLine)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var gerr *C.GError
+	if !gobool(C.g_spawn_command_line_async((*C.gchar)(cstr), &gerr)) {
+		return TakeError(unsafe.Pointer(gerr))
+	}
+	return nil
+}
+
+// SpawnCheckExitStatus is a wrapper around g_spawn_check_exit_status().
+// It returns nil if the wait status describes a successful exit, and
+// otherwise an *Error, in the G_SPAWN_EXIT_ERROR domain with the exit
+// code as its code if the child exited normally.
+func SpawnCheckExitStatus(status int) error {
+	var gerr *C.GError
+	if !gobool(C.g_spawn_check_exit_status(C.gint(status), &gerr)) {
+		return TakeError(unsafe.Pointer(gerr))
+	}
+	return nil
+}
+
+// ChildWatchAdd adds a source to the default main event loop context which
+// calls f with the wait status of the child process pid once it exits,
+// using g_child_watch_source_new().  The child must have been spawned with
+// SPAWN_DO_NOT_REAP_CHILD, as GLib reaps it itself.  The source is
+// removed after f has run.
+func ChildWatchAdd(pid Pid, f func(pid Pid, status int)) (SourceHandle, error) {
+	src := C.g_child_watch_source_new(C.GPid(pid))
+	if src == nil {
+		return 0, errNilPtr
+	}
+	return sourceAttachClosure(src, nil, func(pid uint, status int) bool {
+		f(Pid(pid), status)
+		return false
+	})
+}
@@ -0,0 +1,156 @@
+//GTimeZone : A structure representing a time zone
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"fmt"
+	"runtime"
+	"time"
+	"unsafe"
+)
+
+// TimeType is a representation of GLib's GTimeType.
+type TimeType int
+
+const (
+	TIME_TYPE_STANDARD  TimeType = C.G_TIME_TYPE_STANDARD
+	TIME_TYPE_DAYLIGHT  TimeType = C.G_TIME_TYPE_DAYLIGHT
+	TIME_TYPE_UNIVERSAL TimeType = C.G_TIME_TYPE_UNIVERSAL
+)
+
+// TimeZone is a representation of GLib's GTimeZone.
+type TimeZone struct {
+	GTimeZone *C.GTimeZone
+}
+
+// native returns a pointer to the underlying GTimeZone.
+func (v *TimeZone) native() *C.GTimeZone {
+	if v == nil {
+		return nil
+	}
+	return v.GTimeZone
+}
+
+// Native returns a pointer to the underlying GTimeZone.
+func (v *TimeZone) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// takeTimeZone wraps a GTimeZone, taking ownership of the reference held
+// by the caller.
+func takeTimeZone(c *C.GTimeZone) (*TimeZone, error) {
+	if c == nil {
+		return nil, errNilPtr
+	}
+	tz := &TimeZone{c}
+	runtime.SetFinalizer(tz, (*TimeZone).unref)
+	return tz, nil
+}
+
+func (v *TimeZone) unref() {
+	C.g_time_zone_unref(v.native())
+}
+
+// TimeZoneNew is a wrapper around g_time_zone_new().  identifier is a
+// name from the time zone database, such as "Europe/Paris", or an offset
+// such as "+02:00".  As with g_time_zone_new(), the UTC time zone is
+// returned if identifier cannot be parsed.
+func TimeZoneNew(identifier string) (*TimeZone, error) {
+	cstr := C.CString(identifier)
+	defer C.free(unsafe.Pointer(cstr))
+	return takeTimeZone(C.g_time_zone_new((*C.gchar)(cstr)))
+}
+
+// TimeZoneNewLocal is a wrapper around g_time_zone_new_local().
+func TimeZoneNewLocal() (*TimeZone, error) {
+	return takeTimeZone(C.g_time_zone_new_local())
+}
+
+// TimeZoneNewUTC is a wrapper around g_time_zone_new_utc().
+func TimeZoneNewUTC() (*TimeZone, error) {
+	return takeTimeZone(C.g_time_zone_new_utc())
+}
+
+// TimeZoneNewOffset returns a time zone with the fixed offset from UTC.
+// The offset is rounded down to a whole number of seconds.
+func TimeZoneNewOffset(offset time.Duration) (*TimeZone, error) {
+	return TimeZoneNew(offsetIdentifier(int(offset / time.Second)))
+}
+
+// offsetIdentifier returns the identifier of the time zone with the fixed
+// offset of seconds from UTC.
+func offsetIdentifier(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign, seconds = '-', -seconds
+	}
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if s != 0 {
+		return fmt.Sprintf("%c%02d:%02d:%02d", sign, h, m, s)
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, h, m)
+}
+
+// timeZoneFromLocation returns the time zone best matching the location
+// of t.  The location is looked up by name in the GLib time zone
+// database, and a time zone with the fixed offset of t is returned if the
+// database does not know it or disagrees with Go about the offset at t.
+func timeZoneFromLocation(t time.Time) (*TimeZone, error) {
+	switch t.Location() {
+	case time.UTC:
+		return TimeZoneNewUTC()
+	case time.Local:
+		return TimeZoneNewLocal()
+	}
+
+	_, offset := t.Zone()
+	tz, err := TimeZoneNew(t.Location().String())
+	if err != nil {
+		return nil, err
+	}
+	i := tz.FindInterval(TIME_TYPE_UNIVERSAL, t.Unix())
+	if i < 0 || tz.GetOffset(i) != offset {
+		return TimeZoneNew(offsetIdentifier(offset))
+	}
+	return tz, nil
+}
+
+// FindInterval is a wrapper around g_time_zone_find_interval().  It
+// returns -1 if no interval of the time zone holds t, in seconds since
+// the Unix epoch.
+func (v *TimeZone) FindInterval(timeType TimeType, t int64) int {
+	return int(C.g_time_zone_find_interval(v.native(), C.GTimeType(timeType),
+		C.gint64(t)))
+}
+
+// GetAbbreviation is a wrapper around g_time_zone_get_abbreviation().
+func (v *TimeZone) GetAbbreviation(interval int) string {
+	c := C.g_time_zone_get_abbreviation(v.native(), C.gint(interval))
+	return C.GoString((*C.char)(c))
+}
+
+// GetOffset is a wrapper around g_time_zone_get_offset().  It returns the
+// offset from UTC, in seconds, during interval.
+func (v *TimeZone) GetOffset(interval int) int {
+	return int(C.g_time_zone_get_offset(v.native(), C.gint(interval)))
+}
+
+// GetIdentifier is a wrapper around g_time_zone_get_identifier(), such as
+// "Europe/Paris" or "UTC".  An empty string is returned with GLib versions
+// before 2.58, which do not record identifiers.
+func (v *TimeZone) GetIdentifier() string {
+	c := C._g_time_zone_get_identifier(v.native())
+	if c == nil {
+		return ""
+	}
+	return C.GoString((*C.char)(c))
+}
+
+// IsDst is a wrapper around g_time_zone_is_dst().
+func (v *TimeZone) IsDst(interval int) bool {
+	return gobool(C.g_time_zone_is_dst(v.native(), C.gint(interval)))
+}
@@ -0,0 +1,403 @@
+//GType : GType — The GLib Runtime type identification and management system
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// TypeFromName is a wrapper around g_type_from_name().  TYPE_INVALID is
+// returned if no type is registered with name.
+func TypeFromName(name string) Type {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	return Type(C.g_type_from_name((*C.gchar)(cstr)))
+}
+
+// IsA is a wrapper around g_type_is_a().
+func (t Type) IsA(isAType Type) bool {
+	return gobool(C.g_type_is_a(C.GType(t), C.GType(isAType)))
+}
+
+/*
+ * Go-defined types
+ */
+
+// TypeInfo describes a GObject subclass implemented in Go.
+//
+// Instance is a value, or pointer to a value, of the Go struct type which
+// holds the state of each instance.  A new zeroed struct is allocated
+// whenever the type is instantiated, and is returned by Object.Private.
+// If the struct has an embedded *Object field, it is set to the instance
+// without taking a reference.
+//
+// Each property in Properties is stored in the struct field tagged
+// `property:"name"`, which must be of the Go type GoValue returns for the
+// property (for example, int for TYPE_INT and *Object for object types).
+// Fields are initialized to the default value of their property.  A type
+// derived from another Go type must also store the properties of its Go
+// ancestors, usually by embedding the struct of its parent, as tagged
+// fields of embedded structs are used too.
+//
+// ClassInit, if not nil, is called with a pointer to the native class
+// structure after the properties and signals are installed, so that
+// packages such as gtk may override virtual methods.  InstanceInit, if
+// not nil, is called for each new instance once its Go struct exists.
+// As with instance init functions in C, it runs after the InstanceInit of
+// ancestor types, while the class of the type it belongs to is installed
+// on the instance.
+type TypeInfo struct {
+	Name         string
+	Parent       Type
+	Instance     interface{}
+	Properties   []*ParamSpec
+	Signals      []SignalSpec
+	ClassInit    func(class uintptr)
+	InstanceInit func(obj *Object)
+}
+
+// goType is the registration of a type defined in Go.
+type goType struct {
+	id         uint
+	t          Type
+	info       TypeInfo
+	structType reflect.Type
+	fields     map[string][]int
+}
+
+var (
+	goTypes = struct {
+		sync.RWMutex
+		byID   []*goType
+		byType map[Type]*goType
+	}{
+		byType: make(map[Type]*goType),
+	}
+
+	goInstances = struct {
+		sync.RWMutex
+		m map[*C.GObject]reflect.Value
+	}{
+		m: make(map[*C.GObject]reflect.Value),
+	}
+)
+
+// RegisterType registers a new GObject subclass described by info.  The
+// new type is derived from info.Parent, which must be TYPE_OBJECT or one
+// of its subtypes.  The properties and signals of info are installed the
+// first time the type is instantiated.
+func RegisterType(info *TypeInfo) (Type, error) {
+	if info.Name == "" {
+		return TYPE_INVALID, errors.New("type name must not be empty")
+	}
+	if TypeFromName(info.Name) != TYPE_INVALID {
+		return TYPE_INVALID, errors.New("type " + info.Name + " is already registered")
+	}
+	if !info.Parent.IsA(TYPE_OBJECT) {
+		return TYPE_INVALID, errors.New("parent of " + info.Name + " is not an object type")
+	}
+
+	gt := &goType{info: *info, fields: make(map[string][]int)}
+	if info.Instance != nil {
+		gt.structType = reflect.TypeOf(info.Instance)
+		if gt.structType.Kind() == reflect.Ptr {
+			gt.structType = gt.structType.Elem()
+		}
+		if gt.structType.Kind() != reflect.Struct {
+			return TYPE_INVALID, errors.New("instance of " + info.Name + " is not a struct")
+		}
+		walkFields(gt.structType, nil, func(f reflect.StructField, index []int) {
+			if name := f.Tag.Get("property"); name != "" {
+				gt.fields[name] = index
+			}
+		})
+	}
+	for _, pspec := range info.Properties {
+		if _, ok := gt.fields[pspec.Name()]; !ok {
+			return TYPE_INVALID, fmt.Errorf("no field of %s stores property %s",
+				info.Name, pspec.Name())
+		}
+	}
+	// The struct of the instantiated type stores the properties of its Go
+	// ancestors as well.
+	for anc := lookupGoType(info.Parent); anc != nil; anc = lookupGoType(anc.t.Parent()) {
+		for _, pspec := range anc.info.Properties {
+			if _, ok := gt.fields[pspec.Name()]; !ok {
+				return TYPE_INVALID, fmt.Errorf("no field of %s stores property %s of %s",
+					info.Name, pspec.Name(), anc.info.Name)
+			}
+		}
+	}
+
+	goTypes.Lock()
+	defer goTypes.Unlock()
+
+	gt.id = uint(len(goTypes.byID) + 1)
+	cstr := C.CString(info.Name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C._go_type_register(C.GType(info.Parent), (*C.gchar)(cstr), C.guint(gt.id))
+	if c == C.G_TYPE_INVALID {
+		return TYPE_INVALID, errors.New("unable to register type " + info.Name)
+	}
+	gt.t = Type(c)
+
+	goTypes.byID = append(goTypes.byID, gt)
+	goTypes.byType[gt.t] = gt
+	return gt.t, nil
+}
+
+// lookupGoType returns the registration of t or its closest ancestor
+// defined in Go, or nil if there is none.
+func lookupGoType(t Type) *goType {
+	goTypes.RLock()
+	defer goTypes.RUnlock()
+	for ; t != TYPE_INVALID; t = t.Parent() {
+		if gt, ok := goTypes.byType[t]; ok {
+			return gt
+		}
+	}
+	return nil
+}
+
+// ObjectNew creates a new instance of the object type t with all
+// properties set to their default values.
+func ObjectNew(t Type) (*Object, error) {
+	if !t.IsA(TYPE_OBJECT) {
+		return nil, errors.New(t.Name() + " is not an object type")
+	}
+	c := C._g_object_new(C.GType(t))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	obj := newObject(c)
+	if obj.IsFloating() {
+		obj.RefSink()
+	}
+	runtime.SetFinalizer(obj, (*Object).Unref)
+	return obj, nil
+}
+
+// Private returns a pointer to the Go struct holding the state of an
+// instance of a type registered with RegisterType, or nil if v is not
+// such an instance.
+func (v *Object) Private() interface{} {
+	goInstances.RLock()
+	defer goInstances.RUnlock()
+	if rv, ok := goInstances.m[v.native()]; ok && rv.IsValid() {
+		return rv.Interface()
+	}
+	return nil
+}
+
+// Notify is a wrapper around g_object_notify().
+func (v *Object) Notify(propertyName string) {
+	cstr := C.CString(propertyName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_object_notify(v.native(), (*C.gchar)(cstr))
+}
+
+//export goClassInit
+func goClassInit(gClass C.gpointer, id C.guint) {
+	goTypes.RLock()
+	gt := goTypes.byID[id-1]
+	goTypes.RUnlock()
+
+	class := (*C.GObjectClass)(unsafe.Pointer(gClass))
+	for i, pspec := range gt.info.Properties {
+		C.g_object_class_install_property(class, C.guint(i+1), pspec.native())
+	}
+	for _, spec := range gt.info.Signals {
+		if _, err := SignalNewFromSpec(gt.t, spec); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", gt.info.Name, err)
+		}
+	}
+	if gt.info.ClassInit != nil {
+		gt.info.ClassInit(uintptr(unsafe.Pointer(gClass)))
+	}
+}
+
+//export goInstanceInit
+func goInstanceInit(instance *C.GTypeInstance, gClass C.gpointer) {
+	// GLib calls the instance init of each type from the root down to the
+	// instantiated type, passing the class of the instantiated type and
+	// installing the class of the type being initialized on the instance.
+	final := lookupGoType(Type(C._g_type_from_class(gClass)))
+	if final == nil {
+		return
+	}
+
+	// The Go struct is allocated, for the most derived Go type, by the
+	// first call for the instance.
+	obj := (*C.GObject)(unsafe.Pointer(instance))
+	newGoInstance(final, obj)
+
+	// Only the initializer of the type being initialized runs, so that it
+	// sees its own class on the instance.
+	current := Type(C._g_type_from_instance(C.gpointer(unsafe.Pointer(instance))))
+	goTypes.RLock()
+	gt := goTypes.byType[current]
+	goTypes.RUnlock()
+	if gt != nil && gt.info.InstanceInit != nil {
+		gt.info.InstanceInit(newObject(obj))
+	}
+}
+
+// newGoInstance allocates the Go struct of gt for obj.  It returns false
+// if obj has already been initialized.
+func newGoInstance(gt *goType, obj *C.GObject) bool {
+	goInstances.Lock()
+	defer goInstances.Unlock()
+
+	if _, ok := goInstances.m[obj]; ok {
+		return false
+	}
+	if gt.structType == nil {
+		goInstances.m[obj] = reflect.ValueOf(nil)
+		return true
+	}
+
+	rv := reflect.New(gt.structType)
+	elem := rv.Elem()
+	walkFields(gt.structType, nil, func(f reflect.StructField, index []int) {
+		if f.Anonymous && f.Type == reflect.TypeOf((*Object)(nil)) {
+			elem.FieldByIndex(index).Set(reflect.ValueOf(newObject(obj)))
+		}
+	})
+	for t := gt; t != nil; t = lookupGoType(t.t.Parent()) {
+		for _, pspec := range t.info.Properties {
+			index, ok := gt.fields[pspec.Name()]
+			if !ok {
+				continue
+			}
+			if def, err := pspec.DefaultValue(); err == nil {
+				setField(elem.FieldByIndex(index), def)
+			}
+		}
+	}
+	goInstances.m[obj] = rv
+	return true
+}
+
+// walkFields calls f with each field of the struct type t and its index,
+// descending into embedded structs, such as the struct of a parent type
+// defined in Go.  Embedded pointers are not followed.
+func walkFields(t reflect.Type, index []int, f func(field reflect.StructField, index []int)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fi := append(append([]int(nil), index...), i)
+		f(field, fi)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			walkFields(field.Type, fi, f)
+		}
+	}
+}
+
+//export goObjectFinalize
+func goObjectFinalize(obj *C.GObject) {
+	goInstances.Lock()
+	delete(goInstances.m, obj)
+	goInstances.Unlock()
+}
+
+// instanceField returns the field of the Go state of obj which stores the
+// property name.
+func instanceField(obj *C.GObject, name string) (reflect.Value, error) {
+	goInstances.RLock()
+	rv, ok := goInstances.m[obj]
+	goInstances.RUnlock()
+	if !ok || !rv.IsValid() {
+		return reflect.Value{}, errors.New("object has no Go instance")
+	}
+
+	gt := lookupGoType(Type(C._g_type_from_instance(C.gpointer(unsafe.Pointer(obj)))))
+	index, ok := gt.fields[name]
+	if !ok {
+		return reflect.Value{}, errors.New("no field stores property " + name)
+	}
+	return rv.Elem().FieldByIndex(index), nil
+}
+
+// setField stores val, as returned by GoValue, in the struct field f.
+func setField(f reflect.Value, val interface{}) error {
+	if val == nil {
+		f.Set(reflect.Zero(f.Type()))
+		return nil
+	}
+	rv := reflect.ValueOf(val)
+	switch {
+	case rv.Type().AssignableTo(f.Type()):
+		f.Set(rv)
+	case rv.Type().ConvertibleTo(f.Type()):
+		f.Set(rv.Convert(f.Type()))
+	default:
+		return fmt.Errorf("cannot store %T in field of type %s", val, f.Type())
+	}
+	return nil
+}
+
+//export goObjectSetProperty
+func goObjectSetProperty(obj *C.GObject, id C.guint, value *C.GValue, pspec *C.GParamSpec) {
+	name := C.GoString((*C.char)(pspec.name))
+	f, err := instanceField(obj, name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot set property %s: %v\n", name, err)
+		return
+	}
+
+	v := &Value{*value}
+	val, err := v.GoValue()
+	if err == nil {
+		err = setField(f, val)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot set property %s: %v\n", name, err)
+	}
+}
+
+//export goObjectGetProperty
+func goObjectGetProperty(obj *C.GObject, id C.guint, value *C.GValue, pspec *C.GParamSpec) {
+	name := C.GoString((*C.char)(pspec.name))
+	f, err := instanceField(obj, name)
+	if err == nil {
+		err = storeGValue(value, f.Interface())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot get property %s: %v\n", name, err)
+	}
+}
+
+// storeGValue stores the Go value val in the initialized GValue dst,
+// converting it to the type of dst if needed.
+func storeGValue(dst *C.GValue, val interface{}) error {
+	t := Type(C._g_value_type(dst))
+	if Type(C._g_value_fundamental(C.GType(t))) == TYPE_OBJECT {
+		if rv := reflect.ValueOf(val); !rv.IsValid() ||
+			(rv.Kind() == reflect.Ptr && rv.IsNil()) {
+			C.g_value_set_object(dst, nil)
+			return nil
+		}
+		if obj, ok := val.(IObject); ok {
+			C.g_value_set_object(dst, C.gpointer(obj.toGObject()))
+			return nil
+		}
+	}
+
+	gv, err := GValue(val)
+	if err != nil {
+		return err
+	}
+	if !gobool(C.g_value_transform(gv.native(), dst)) {
+		return fmt.Errorf("cannot convert %T to %s", val, t.Name())
+	}
+	return nil
+}
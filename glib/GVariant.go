@@ -6,7 +6,13 @@ package glib
 // #include <glib-object.h>
 // #include "glib.go.h"
 import "C"
-import "unsafe"
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"unsafe"
+)
 
 /*
  * GVariant
@@ -41,8 +47,21 @@ func newVariant(p *C.GVariant) *Variant {
 	return &Variant{GVariant: p}
 }
 
-func VariantFromUnsafePointer(p unsafe.Pointer) *Variant{
-	return &Variant{ C.toGVariant(p) }
+// takeVariant creates a new Variant from a GVariant pointer which is either
+// floating or already owned by the caller.  The floating reference, if any,
+// is taken and a runtime finalizer is set to release it.  takeVariant
+// returns nil if p is nil.
+func takeVariant(p *C.GVariant) *Variant {
+	if p == nil {
+		return nil
+	}
+	v := newVariant(C.g_variant_take_ref(p))
+	runtime.SetFinalizer(v, (*Variant).Unref)
+	return v
+}
+
+func VariantFromUnsafePointer(p unsafe.Pointer) *Variant {
+	return &Variant{C.toGVariant(p)}
 }
 
 // native returns a pointer to the underlying GVariant.
@@ -59,126 +78,633 @@ func (v *Variant) Native() uintptr {
 	return uintptr(unsafe.Pointer(v.native()))
 }
 
-//void	g_variant_unref ()
-//GVariant *	g_variant_ref ()
-//GVariant *	g_variant_ref_sink ()
-//gboolean	g_variant_is_floating ()
-//GVariant *	g_variant_take_ref ()
-//const GVariantType *	g_variant_get_type ()
-//const gchar *	g_variant_get_type_string ()
-//gboolean	g_variant_is_of_type ()
-//gboolean	g_variant_is_container ()
-//gint	g_variant_compare ()
-//GVariantClass	g_variant_classify ()
+// Unref is a wrapper around g_variant_unref().
+func (v *Variant) Unref() {
+	C.g_variant_unref(v.native())
+}
+
+// Ref is a wrapper around g_variant_ref().
+func (v *Variant) Ref() {
+	C.g_variant_ref(v.native())
+}
+
+// RefSink is a wrapper around g_variant_ref_sink().
+func (v *Variant) RefSink() {
+	C.g_variant_ref_sink(v.native())
+}
+
+// IsFloating is a wrapper around g_variant_is_floating().
+func (v *Variant) IsFloating() bool {
+	return gobool(C.g_variant_is_floating(v.native()))
+}
+
+// TakeRef is a wrapper around g_variant_take_ref().
+func (v *Variant) TakeRef() {
+	C.g_variant_take_ref(v.native())
+}
+
+// Type is a wrapper around g_variant_get_type().  The returned VariantType
+// keeps v alive.
+func (v *Variant) Type() *VariantType {
+	c := C.g_variant_get_type(v.native())
+	return borrowVariantType(c, v)
+}
+
+// TypeString is a wrapper around g_variant_get_type_string().
+func (v *Variant) TypeString() string {
+	c := C.g_variant_get_type_string(v.native())
+	return C.GoString((*C.char)(c))
+}
+
+// IsOfType is a wrapper around g_variant_is_of_type().
+func (v *Variant) IsOfType(t *VariantType) bool {
+	return gobool(C.g_variant_is_of_type(v.native(), t.native()))
+}
+
+// IsContainer is a wrapper around g_variant_is_container().
+func (v *Variant) IsContainer() bool {
+	return gobool(C.g_variant_is_container(v.native()))
+}
+
+// Compare is a wrapper around g_variant_compare().  Both variants must
+// be of the same basic type; containers may not be compared.
+func (v *Variant) Compare(other *Variant) int {
+	c := C.g_variant_compare(C.gconstpointer(unsafe.Pointer(v.native())),
+		C.gconstpointer(unsafe.Pointer(other.native())))
+	return int(c)
+}
+
+// Classify is a wrapper around g_variant_classify().
+func (v *Variant) Classify() VariantClass {
+	return VariantClass(C.g_variant_classify(v.native()))
+}
+
 //gboolean	g_variant_check_format_string ()
 //void	g_variant_get ()
 //void	g_variant_get_va ()
 //GVariant *	g_variant_new ()
 //GVariant *	g_variant_new_va ()
-//GVariant *	g_variant_new_boolean ()
-//GVariant *	g_variant_new_byte ()
-//GVariant *	g_variant_new_int16 ()
-//GVariant *	g_variant_new_uint16 ()
-//GVariant *	g_variant_new_int32 ()
-//GVariant *	g_variant_new_uint32 ()
-//GVariant *	g_variant_new_int64 ()
-//GVariant *	g_variant_new_uint64 ()
-//GVariant *	g_variant_new_handle ()
-//GVariant *	g_variant_new_double ()
-//GVariant *	g_variant_new_string ()
+
+// VariantNewBoolean is a wrapper around g_variant_new_boolean().
+func VariantNewBoolean(value bool) *Variant {
+	return takeVariant(C.g_variant_new_boolean(gbool(value)))
+}
+
+// VariantNewByte is a wrapper around g_variant_new_byte().
+func VariantNewByte(value uint8) *Variant {
+	return takeVariant(C.g_variant_new_byte(C.guchar(value)))
+}
+
+// VariantNewInt16 is a wrapper around g_variant_new_int16().
+func VariantNewInt16(value int16) *Variant {
+	return takeVariant(C.g_variant_new_int16(C.gint16(value)))
+}
+
+// VariantNewUint16 is a wrapper around g_variant_new_uint16().
+func VariantNewUint16(value uint16) *Variant {
+	return takeVariant(C.g_variant_new_uint16(C.guint16(value)))
+}
+
+// VariantNewInt32 is a wrapper around g_variant_new_int32().
+func VariantNewInt32(value int32) *Variant {
+	return takeVariant(C.g_variant_new_int32(C.gint32(value)))
+}
+
+// VariantNewUint32 is a wrapper around g_variant_new_uint32().
+func VariantNewUint32(value uint32) *Variant {
+	return takeVariant(C.g_variant_new_uint32(C.guint32(value)))
+}
+
+// VariantNewInt64 is a wrapper around g_variant_new_int64().
+func VariantNewInt64(value int64) *Variant {
+	return takeVariant(C.g_variant_new_int64(C.gint64(value)))
+}
+
+// VariantNewUint64 is a wrapper around g_variant_new_uint64().
+func VariantNewUint64(value uint64) *Variant {
+	return takeVariant(C.g_variant_new_uint64(C.guint64(value)))
+}
+
+// VariantNewHandle is a wrapper around g_variant_new_handle().
+func VariantNewHandle(value int32) *Variant {
+	return takeVariant(C.g_variant_new_handle(C.gint32(value)))
+}
+
+// VariantNewDouble is a wrapper around g_variant_new_double().
+func VariantNewDouble(value float64) *Variant {
+	return takeVariant(C.g_variant_new_double(C.gdouble(value)))
+}
+
+// VariantNewString is a wrapper around g_variant_new_string().
+func VariantNewString(value string) *Variant {
+	cstr := C.CString(value)
+	defer C.free(unsafe.Pointer(cstr))
+	return takeVariant(C.g_variant_new_string((*C.gchar)(cstr)))
+}
+
 //GVariant *	g_variant_new_take_string ()
 //GVariant *	g_variant_new_printf ()
-//GVariant *	g_variant_new_object_path ()
-//gboolean	g_variant_is_object_path ()
-//GVariant *	g_variant_new_signature ()
-//gboolean	g_variant_is_signature ()
-//GVariant *	g_variant_new_variant ()
-//GVariant *	g_variant_new_strv ()
-//GVariant *	g_variant_new_objv ()
-//GVariant *	g_variant_new_bytestring ()
-//GVariant *	g_variant_new_bytestring_array ()
-//gboolean	g_variant_get_boolean ()
-//guchar	g_variant_get_byte ()
-//gint16	g_variant_get_int16 ()
-//guint16	g_variant_get_uint16 ()
-//gint32	g_variant_get_int32 ()
-//guint32	g_variant_get_uint32 ()
-//gint64	g_variant_get_int64 ()
-//guint64	g_variant_get_uint64 ()
-//gint32	g_variant_get_handle ()
-//gdouble	g_variant_get_double ()
-//const gchar *	g_variant_get_string ()
+
+// VariantNewObjectPath is a wrapper around g_variant_new_object_path().
+// A non-nil error is returned if path is not a valid D-Bus object path.
+func VariantNewObjectPath(path string) (*Variant, error) {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	if !gobool(C.g_variant_is_object_path((*C.gchar)(cstr))) {
+		return nil, errors.New("invalid object path: " + path)
+	}
+	return takeVariant(C.g_variant_new_object_path((*C.gchar)(cstr))), nil
+}
+
+// VariantIsObjectPath is a wrapper around g_variant_is_object_path().
+func VariantIsObjectPath(path string) bool {
+	cstr := C.CString(path)
+	defer C.free(unsafe.Pointer(cstr))
+	return gobool(C.g_variant_is_object_path((*C.gchar)(cstr)))
+}
+
+// VariantNewSignature is a wrapper around g_variant_new_signature().
+// A non-nil error is returned if signature is not a valid D-Bus type
+// signature.
+func VariantNewSignature(signature string) (*Variant, error) {
+	cstr := C.CString(signature)
+	defer C.free(unsafe.Pointer(cstr))
+	if !gobool(C.g_variant_is_signature((*C.gchar)(cstr))) {
+		return nil, errors.New("invalid signature: " + signature)
+	}
+	return takeVariant(C.g_variant_new_signature((*C.gchar)(cstr))), nil
+}
+
+// VariantIsSignature is a wrapper around g_variant_is_signature().
+func VariantIsSignature(signature string) bool {
+	cstr := C.CString(signature)
+	defer C.free(unsafe.Pointer(cstr))
+	return gobool(C.g_variant_is_signature((*C.gchar)(cstr)))
+}
+
+// VariantNewVariant is a wrapper around g_variant_new_variant().
+func VariantNewVariant(value *Variant) *Variant {
+	return takeVariant(C.g_variant_new_variant(value.native()))
+}
+
+// VariantNewStrv is a wrapper around g_variant_new_strv().
+func VariantNewStrv(strv []string) *Variant {
+	cstrv := cStrv(strv)
+	defer freeStrv(cstrv, len(strv))
+	return takeVariant(C.g_variant_new_strv(cstrv, C.gssize(len(strv))))
+}
+
+// VariantNewObjv is a wrapper around g_variant_new_objv().  A non-nil
+// error is returned if any element of objv is not a valid object path.
+func VariantNewObjv(objv []string) (*Variant, error) {
+	for _, path := range objv {
+		if !VariantIsObjectPath(path) {
+			return nil, errors.New("invalid object path: " + path)
+		}
+	}
+	cstrv := cStrv(objv)
+	defer freeStrv(cstrv, len(objv))
+	return takeVariant(C.g_variant_new_objv(cstrv, C.gssize(len(objv)))), nil
+}
+
+// VariantNewBytestring is a wrapper around g_variant_new_bytestring().
+// The bytestring is terminated at the first nul byte, if any.
+func VariantNewBytestring(value []byte) *Variant {
+	cstr := C.CString(string(value))
+	defer C.free(unsafe.Pointer(cstr))
+	return takeVariant(C.g_variant_new_bytestring((*C.gchar)(cstr)))
+}
+
+// VariantNewBytestringArray is a wrapper around
+// g_variant_new_bytestring_array().
+func VariantNewBytestringArray(values [][]byte) *Variant {
+	strs := make([]string, len(values))
+	for i := range values {
+		strs[i] = string(values[i])
+	}
+	cstrv := cStrv(strs)
+	defer freeStrv(cstrv, len(strs))
+	return takeVariant(C.g_variant_new_bytestring_array(cstrv, C.gssize(len(strs))))
+}
+
+// GetBoolean is a wrapper around g_variant_get_boolean().
+func (v *Variant) GetBoolean() bool {
+	return gobool(C.g_variant_get_boolean(v.native()))
+}
+
+// GetByte is a wrapper around g_variant_get_byte().
+func (v *Variant) GetByte() uint8 {
+	return uint8(C.g_variant_get_byte(v.native()))
+}
+
+// GetInt16 is a wrapper around g_variant_get_int16().
+func (v *Variant) GetInt16() int16 {
+	return int16(C.g_variant_get_int16(v.native()))
+}
+
+// GetUint16 is a wrapper around g_variant_get_uint16().
+func (v *Variant) GetUint16() uint16 {
+	return uint16(C.g_variant_get_uint16(v.native()))
+}
+
+// GetInt32 is a wrapper around g_variant_get_int32().
+func (v *Variant) GetInt32() int32 {
+	return int32(C.g_variant_get_int32(v.native()))
+}
+
+// GetUint32 is a wrapper around g_variant_get_uint32().
+func (v *Variant) GetUint32() uint32 {
+	return uint32(C.g_variant_get_uint32(v.native()))
+}
+
+// GetInt64 is a wrapper around g_variant_get_int64().
+func (v *Variant) GetInt64() int64 {
+	return int64(C.g_variant_get_int64(v.native()))
+}
+
+// GetUint64 is a wrapper around g_variant_get_uint64().
+func (v *Variant) GetUint64() uint64 {
+	return uint64(C.g_variant_get_uint64(v.native()))
+}
+
+// GetHandle is a wrapper around g_variant_get_handle().
+func (v *Variant) GetHandle() int32 {
+	return int32(C.g_variant_get_handle(v.native()))
+}
+
+// GetDouble is a wrapper around g_variant_get_double().
+func (v *Variant) GetDouble() float64 {
+	return float64(C.g_variant_get_double(v.native()))
+}
+
+// GetString is a wrapper around g_variant_get_string().  It may be used
+// on string, object path and signature variants.
+func (v *Variant) GetString() string {
+	var length C.gsize
+	c := C.g_variant_get_string(v.native(), &length)
+	return C.GoStringN((*C.char)(c), C.int(length))
+}
+
 //gchar *	g_variant_dup_string ()
-//GVariant *	g_variant_get_variant ()
-//const gchar **	g_variant_get_strv ()
+
+// GetVariant is a wrapper around g_variant_get_variant().
+func (v *Variant) GetVariant() *Variant {
+	return takeVariant(C.g_variant_get_variant(v.native()))
+}
+
+// GetStrv is a wrapper around g_variant_get_strv().
+func (v *Variant) GetStrv() []string {
+	var length C.gsize
+	c := C.g_variant_get_strv(v.native(), &length)
+	defer C.g_free(C.gpointer(c))
+	return goStrv(c, int(length))
+}
+
 //gchar **	g_variant_dup_strv ()
-//const gchar **	g_variant_get_objv ()
+
+// GetObjv is a wrapper around g_variant_get_objv().
+func (v *Variant) GetObjv() []string {
+	var length C.gsize
+	c := C.g_variant_get_objv(v.native(), &length)
+	defer C.g_free(C.gpointer(c))
+	return goStrv(c, int(length))
+}
+
 //gchar **	g_variant_dup_objv ()
-//const gchar *	g_variant_get_bytestring ()
+
+// GetBytestring is a wrapper around g_variant_get_bytestring().
+func (v *Variant) GetBytestring() []byte {
+	c := C.g_variant_get_bytestring(v.native())
+	return []byte(C.GoString((*C.char)(c)))
+}
+
 //gchar *	g_variant_dup_bytestring ()
-//const gchar **	g_variant_get_bytestring_array ()
+
+// GetBytestringArray is a wrapper around g_variant_get_bytestring_array().
+func (v *Variant) GetBytestringArray() [][]byte {
+	var length C.gsize
+	c := C.g_variant_get_bytestring_array(v.native(), &length)
+	defer C.g_free(C.gpointer(c))
+	strs := goStrv(c, int(length))
+	values := make([][]byte, len(strs))
+	for i := range strs {
+		values[i] = []byte(strs[i])
+	}
+	return values
+}
+
 //gchar **	g_variant_dup_bytestring_array ()
-//GVariant *	g_variant_new_maybe ()
-//GVariant *	g_variant_new_array ()
-//GVariant *	g_variant_new_tuple ()
-//GVariant *	g_variant_new_dict_entry ()
+
+// VariantNewMaybe is a wrapper around g_variant_new_maybe().  childType
+// may be nil if child is non-nil.  A nil child creates a Nothing value of
+// type maybe childType.
+func VariantNewMaybe(childType *VariantType, child *Variant) (*Variant, error) {
+	if childType == nil && child == nil {
+		return nil, errors.New("childType and child may not both be nil")
+	}
+	if childType != nil && child != nil && !child.IsOfType(childType) {
+		return nil, errors.New("child is not of type childType")
+	}
+	return takeVariant(C.g_variant_new_maybe(childType.native(), child.native())), nil
+}
+
+// VariantNewArray is a wrapper around g_variant_new_array().  childType
+// may be nil if at least one child is given.  All children must be of the
+// same type.
+func VariantNewArray(childType *VariantType, children ...*Variant) (*Variant, error) {
+	if childType == nil && len(children) == 0 {
+		return nil, errors.New("childType may only be nil if children are given")
+	}
+	for i := range children {
+		if children[i] == nil {
+			return nil, errors.New("nil array element")
+		}
+		if childType != nil && !children[i].IsOfType(childType) {
+			return nil, errors.New("array element is not of type childType")
+		}
+		if children[i].TypeString() != children[0].TypeString() {
+			return nil, errors.New("array elements differ in type")
+		}
+	}
+	list := variantList(children)
+	defer C.g_free(C.gpointer(list))
+	c := C.g_variant_new_array(childType.native(), list, C.gsize(len(children)))
+	return takeVariant(c), nil
+}
+
+// VariantNewTuple is a wrapper around g_variant_new_tuple().
+func VariantNewTuple(children ...*Variant) (*Variant, error) {
+	for i := range children {
+		if children[i] == nil {
+			return nil, errors.New("nil tuple element")
+		}
+	}
+	list := variantList(children)
+	defer C.g_free(C.gpointer(list))
+	c := C.g_variant_new_tuple(list, C.gsize(len(children)))
+	return takeVariant(c), nil
+}
+
+// VariantNewDictEntry is a wrapper around g_variant_new_dict_entry().
+// key must be a variant of a basic type.
+func VariantNewDictEntry(key, value *Variant) (*Variant, error) {
+	if key == nil || value == nil {
+		return nil, errors.New("key and value must be non-nil")
+	}
+	if !gobool(C.g_variant_type_is_basic(key.Type().native())) {
+		return nil, errors.New("dict entry key must be of a basic type")
+	}
+	return takeVariant(C.g_variant_new_dict_entry(key.native(), value.native())), nil
+}
+
+// variantList allocates a C array holding the GVariant pointers of vs.
+// The array must be freed with g_free().
+func variantList(vs []*Variant) **C.GVariant {
+	list := C.alloc_variant_list(C.int(len(vs)))
+	for i := range vs {
+		C.variant_list_insert(list, C.int(i), vs[i].native())
+	}
+	return list
+}
+
 //GVariant *	g_variant_new_fixed_array ()
-//GVariant *	g_variant_get_maybe ()
-//gsize	g_variant_n_children ()
-//GVariant *	g_variant_get_child_value ()
+
+// GetMaybe is a wrapper around g_variant_get_maybe().  nil is returned
+// if v holds Nothing.
+func (v *Variant) GetMaybe() *Variant {
+	return takeVariant(C.g_variant_get_maybe(v.native()))
+}
+
+// NChildren is a wrapper around g_variant_n_children().
+func (v *Variant) NChildren() uint {
+	return uint(C.g_variant_n_children(v.native()))
+}
+
+// GetChildValue is a wrapper around g_variant_get_child_value().  A
+// non-nil error is returned if index is out of range.
+func (v *Variant) GetChildValue(index uint) (*Variant, error) {
+	if index >= v.NChildren() {
+		return nil, errors.New("child index out of range")
+	}
+	return takeVariant(C.g_variant_get_child_value(v.native(), C.gsize(index))), nil
+}
+
 //void	g_variant_get_child ()
-//GVariant *	g_variant_lookup_value ()
+
+// LookupValue is a wrapper around g_variant_lookup_value().  v must be a
+// dictionary (an array of dict entries with string keys).  expectedType
+// may be nil to accept a value of any type.  nil is returned if key is not
+// present or its value does not match expectedType.
+func (v *Variant) LookupValue(key string, expectedType *VariantType) *Variant {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_variant_lookup_value(v.native(), (*C.gchar)(cstr), expectedType.native())
+	return takeVariant(c)
+}
+
 //gboolean	g_variant_lookup ()
 //gconstpointer	g_variant_get_fixed_array ()
-//gsize	g_variant_get_size ()
-//gconstpointer	g_variant_get_data ()
-//GBytes *	g_variant_get_data_as_bytes ()
+
+// GetSize is a wrapper around g_variant_get_size().
+func (v *Variant) GetSize() uint {
+	return uint(C.g_variant_get_size(v.native()))
+}
+
+// Data is a wrapper around g_variant_get_data().  It returns a copy of the
+// serialized form of v, in machine byte order.
+func (v *Variant) Data() []byte {
+	n := C.g_variant_get_size(v.native())
+	if n == 0 {
+		return []byte{}
+	}
+	p := C.g_variant_get_data(v.native())
+	return C.GoBytes(unsafe.Pointer(p), C.int(n))
+}
+
+// DataAsBytes is a wrapper around g_variant_get_data_as_bytes() and
+// returns the serialized data of v without copying it.
+func (v *Variant) DataAsBytes() *Bytes {
+	return takeBytes(C.g_variant_get_data_as_bytes(v.native()))
+}
+
 //void	g_variant_store ()
 //GVariant *	g_variant_new_from_data ()
-//GVariant *	g_variant_new_from_bytes ()
-//GVariant *	g_variant_byteswap ()
-//GVariant *	g_variant_get_normal_form ()
-//gboolean	g_variant_is_normal_form ()
-//guint	g_variant_hash ()
-//gboolean	g_variant_equal ()
-//gchar *	g_variant_print ()
+
+// VariantNewFromBytes is a wrapper around g_variant_new_from_bytes().  data
+// is copied and interpreted as the serialized form of a variant of type t
+// in machine byte order.  Unless trusted is true, the data is not assumed
+// to be in normal form and is validated when accessed.
+func VariantNewFromBytes(t *VariantType, data []byte, trusted bool) (*Variant, error) {
+	if t == nil || !gobool(C.g_variant_type_is_definite(t.native())) {
+		return nil, errors.New("variant type must be definite")
+	}
+	var p unsafe.Pointer
+	if len(data) > 0 {
+		p = unsafe.Pointer(&data[0])
+	}
+	bytes := C.g_bytes_new(C.gconstpointer(p), C.gsize(len(data)))
+	defer C.g_bytes_unref(bytes)
+	c := C.g_variant_new_from_bytes(t.native(), bytes, gbool(trusted))
+	return takeVariant(c), nil
+}
+
+// Byteswap is a wrapper around g_variant_byteswap().
+func (v *Variant) Byteswap() *Variant {
+	return takeVariant(C.g_variant_byteswap(v.native()))
+}
+
+// GetNormalForm is a wrapper around g_variant_get_normal_form().
+func (v *Variant) GetNormalForm() *Variant {
+	return takeVariant(C.g_variant_get_normal_form(v.native()))
+}
+
+// IsNormalForm is a wrapper around g_variant_is_normal_form().
+func (v *Variant) IsNormalForm() bool {
+	return gobool(C.g_variant_is_normal_form(v.native()))
+}
+
+// Hash is a wrapper around g_variant_hash().  v must be of a basic type.
+func (v *Variant) Hash() uint {
+	return uint(C.g_variant_hash(C.gconstpointer(unsafe.Pointer(v.native()))))
+}
+
+// Equal is a wrapper around g_variant_equal().
+func (v *Variant) Equal(other *Variant) bool {
+	c := C.g_variant_equal(C.gconstpointer(unsafe.Pointer(v.native())),
+		C.gconstpointer(unsafe.Pointer(other.native())))
+	return gobool(c)
+}
+
+// Print is a wrapper around g_variant_print().  If typeAnnotate is true,
+// type information is included in the output where it would otherwise be
+// ambiguous, so that the result may be read back by VariantParse with a
+// nil type.
+func (v *Variant) Print(typeAnnotate bool) string {
+	c := C.g_variant_print(v.native(), gbool(typeAnnotate))
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
 //GString *	g_variant_print_string ()
-//GVariantIter *	g_variant_iter_copy ()
-//void	g_variant_iter_free ()
-//gsize	g_variant_iter_init ()
-//gsize	g_variant_iter_n_children ()
-//GVariantIter *	g_variant_iter_new ()
-//GVariant *	g_variant_iter_next_value ()
-//gboolean	g_variant_iter_next ()
-//gboolean	g_variant_iter_loop ()
-//void	g_variant_builder_unref ()
-//GVariantBuilder *	g_variant_builder_ref ()
-//GVariantBuilder *	g_variant_builder_new ()
-//void	g_variant_builder_init ()
-//void	g_variant_builder_clear ()
-//void	g_variant_builder_add_value ()
-//void	g_variant_builder_add ()
-//void	g_variant_builder_add_parsed ()
-//GVariant *	g_variant_builder_end ()
-//void	g_variant_builder_open ()
-//void	g_variant_builder_close ()
-//void	g_variant_dict_unref ()
-//GVariantDict *	g_variant_dict_ref ()
-//GVariantDict *	g_variant_dict_new ()
-//void	g_variant_dict_init ()
-//void	g_variant_dict_clear ()
-//gboolean	g_variant_dict_contains ()
-//gboolean	g_variant_dict_lookup ()
-//GVariant *	g_variant_dict_lookup_value ()
-//void	g_variant_dict_insert ()
-//void	g_variant_dict_insert_value ()
-//gboolean	g_variant_dict_remove ()
-//GVariant *	g_variant_dict_end ()
-//#define	G_VARIANT_PARSE_ERROR
-//GVariant *	g_variant_parse ()
+
+// VariantParseErrorCode is a representation of GLib's GVariantParseError.
+type VariantParseErrorCode int
+
+const (
+	VARIANT_PARSE_ERROR_FAILED                       VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_FAILED
+	VARIANT_PARSE_ERROR_BASIC_TYPE_EXPECTED          VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_BASIC_TYPE_EXPECTED
+	VARIANT_PARSE_ERROR_CANNOT_INFER_TYPE            VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_CANNOT_INFER_TYPE
+	VARIANT_PARSE_ERROR_DEFINITE_TYPE_EXPECTED       VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_DEFINITE_TYPE_EXPECTED
+	VARIANT_PARSE_ERROR_INPUT_NOT_AT_END             VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_INPUT_NOT_AT_END
+	VARIANT_PARSE_ERROR_INVALID_CHARACTER            VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_INVALID_CHARACTER
+	VARIANT_PARSE_ERROR_INVALID_FORMAT_STRING        VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_INVALID_FORMAT_STRING
+	VARIANT_PARSE_ERROR_INVALID_OBJECT_PATH          VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_INVALID_OBJECT_PATH
+	VARIANT_PARSE_ERROR_INVALID_SIGNATURE            VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_INVALID_SIGNATURE
+	VARIANT_PARSE_ERROR_INVALID_TYPE_STRING          VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_INVALID_TYPE_STRING
+	VARIANT_PARSE_ERROR_NO_COMMON_TYPE               VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_NO_COMMON_TYPE
+	VARIANT_PARSE_ERROR_NUMBER_OUT_OF_RANGE          VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_NUMBER_OUT_OF_RANGE
+	VARIANT_PARSE_ERROR_NUMBER_TOO_BIG               VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_NUMBER_TOO_BIG
+	VARIANT_PARSE_ERROR_TYPE_ERROR                   VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_TYPE_ERROR
+	VARIANT_PARSE_ERROR_UNEXPECTED_TOKEN             VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_UNEXPECTED_TOKEN
+	VARIANT_PARSE_ERROR_UNKNOWN_KEYWORD              VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_UNKNOWN_KEYWORD
+	VARIANT_PARSE_ERROR_UNTERMINATED_STRING_CONSTANT VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_UNTERMINATED_STRING_CONSTANT
+	VARIANT_PARSE_ERROR_VALUE_EXPECTED               VariantParseErrorCode = C.G_VARIANT_PARSE_ERROR_VALUE_EXPECTED
+)
+
+// VariantParseRange is a range of byte offsets into the text given to
+// VariantParse.  End is exclusive.  A single position is represented by
+// a range where Start equals End.
+type VariantParseRange struct {
+	Start, End int
+}
+
+// VariantParseError is the error returned by VariantParse.
+type VariantParseError struct {
+	Code VariantParseErrorCode
+
+	// Message is the description of the error, without position
+	// information.
+	Message string
+
+	// Ranges holds the one or two ranges of the source text that the
+	// error applies to.
+	Ranges []VariantParseRange
+
+	// Context is the result of g_variant_parse_error_print_context(): a
+	// human readable, multi-line rendering of the error with the relevant
+	// parts of the source text underlined.
+	Context string
+
+	gerror *Error
+}
+
+func (e *VariantParseError) Error() string {
+	return e.gerror.Message
+}
+
+// Unwrap returns the *Error of the G_VARIANT_PARSE_ERROR domain which e
+// was created from.
+func (e *VariantParseError) Unwrap() error {
+	return e.gerror
+}
+
+// newVariantParseError creates a VariantParseError from a GError set by
+// g_variant_parse().  The GError is not freed.
+func newVariantParseError(err *C.GError, source *C.gchar) *VariantParseError {
+	gerror := newError(err)
+	msg := gerror.Message
+	e := &VariantParseError{
+		Code:    VariantParseErrorCode(err.code),
+		Message: msg,
+		gerror:  gerror,
+	}
+
+	// GLib prefixes the message with the positions of the error, in the
+	// form "a:", "a-b:" or "a-b,c-d:".
+	if i := strings.IndexByte(msg, ':'); i > 0 {
+		var ranges []VariantParseRange
+		for _, field := range strings.Split(msg[:i], ",") {
+			var r VariantParseRange
+			if _, err := fmt.Sscanf(field, "%d-%d", &r.Start, &r.End); err != nil {
+				if _, err := fmt.Sscanf(field, "%d", &r.Start); err != nil {
+					ranges = nil
+					break
+				}
+				r.End = r.Start
+			}
+			ranges = append(ranges, r)
+		}
+		if ranges != nil {
+			e.Ranges = ranges
+			e.Message = msg[i+1:]
+		}
+	}
+
+	ctx := C.g_variant_parse_error_print_context(err, source)
+	defer C.g_free(C.gpointer(ctx))
+	e.Context = C.GoString((*C.char)(ctx))
+	return e
+}
+
+// VariantParse is a wrapper around g_variant_parse().  t may be nil to
+// infer the type from text, in which case text must be unambiguous.  The
+// whole of text must be consumed.  If text cannot be parsed, the returned
+// error is a *VariantParseError.
+func VariantParse(t *VariantType, text string) (*Variant, error) {
+	cstr := C.CString(text)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var err *C.GError
+	c := C.g_variant_parse(t.native(), (*C.gchar)(cstr), nil, nil, &err)
+	if c == nil {
+		defer C.g_error_free(err)
+		return nil, newVariantParseError(err, (*C.gchar)(cstr))
+	}
+	return takeVariant(c), nil
+}
+
 //GVariant *	g_variant_new_parsed_va ()
 //GVariant *	g_variant_new_parsed ()
-//gchar *	g_variant_parse_error_print_context ()
 
 /*
  * GVariantClass
@@ -246,6 +772,53 @@ func (v *VariantIter) Native() uintptr {
 	return uintptr(unsafe.Pointer(v.native()))
 }
 
+// VariantIterNew is a wrapper around g_variant_iter_new().  value must be
+// a container.  A runtime finalizer is set to free the iterator.
+func VariantIterNew(value *Variant) (*VariantIter, error) {
+	if !value.IsContainer() {
+		return nil, errors.New("value is not a container")
+	}
+	c := C.g_variant_iter_new(value.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	iter := newVariantIter(c)
+	runtime.SetFinalizer(iter, (*VariantIter).free)
+	return iter, nil
+}
+
+// Iter is a convenience wrapper around VariantIterNew.
+func (v *Variant) Iter() (*VariantIter, error) {
+	return VariantIterNew(v)
+}
+
+func (v *VariantIter) free() {
+	C.g_variant_iter_free(v.native())
+}
+
+// Copy is a wrapper around g_variant_iter_copy().
+func (v *VariantIter) Copy() *VariantIter {
+	iter := newVariantIter(C.g_variant_iter_copy(v.native()))
+	runtime.SetFinalizer(iter, (*VariantIter).free)
+	return iter
+}
+
+//gsize	g_variant_iter_init ()
+
+// NChildren is a wrapper around g_variant_iter_n_children().
+func (v *VariantIter) NChildren() uint {
+	return uint(C.g_variant_iter_n_children(v.native()))
+}
+
+// NextValue is a wrapper around g_variant_iter_next_value().  nil is
+// returned once the end of the container has been reached.
+func (v *VariantIter) NextValue() *Variant {
+	return takeVariant(C.g_variant_iter_next_value(v.native()))
+}
+
+//gboolean	g_variant_iter_next ()
+//gboolean	g_variant_iter_loop ()
+
 /*
  * GVariantBuilder
  */
@@ -285,6 +858,61 @@ func (v *VariantBuilder) Native() uintptr {
 	return uintptr(unsafe.Pointer(v.native()))
 }
 
+// VariantBuilderNew is a wrapper around g_variant_builder_new().  t must
+// be an array, maybe, tuple, dict entry or variant type.  A runtime
+// finalizer is set to release the builder.
+func VariantBuilderNew(t *VariantType) (*VariantBuilder, error) {
+	if t == nil {
+		return nil, errors.New("builder type may not be nil")
+	}
+	if !gobool(C.g_variant_type_is_container(t.native())) {
+		return nil, errors.New("builder type is not a container type")
+	}
+	c := C.g_variant_builder_new(t.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	b := newVariantBuilder(c)
+	runtime.SetFinalizer(b, (*VariantBuilder).Unref)
+	return b, nil
+}
+
+// Unref is a wrapper around g_variant_builder_unref().
+func (v *VariantBuilder) Unref() {
+	C.g_variant_builder_unref(v.native())
+}
+
+// Ref is a wrapper around g_variant_builder_ref().
+func (v *VariantBuilder) Ref() {
+	C.g_variant_builder_ref(v.native())
+}
+
+//void	g_variant_builder_init ()
+//void	g_variant_builder_clear ()
+
+// AddValue is a wrapper around g_variant_builder_add_value().
+func (v *VariantBuilder) AddValue(value *Variant) {
+	C.g_variant_builder_add_value(v.native(), value.native())
+}
+
+//void	g_variant_builder_add ()
+//void	g_variant_builder_add_parsed ()
+
+// End is a wrapper around g_variant_builder_end().  The builder may not
+// be used again after calling End.
+func (v *VariantBuilder) End() *Variant {
+	return takeVariant(C.g_variant_builder_end(v.native()))
+}
+
+// Open is a wrapper around g_variant_builder_open().
+func (v *VariantBuilder) Open(t *VariantType) {
+	C.g_variant_builder_open(v.native(), t.native())
+}
+
+// Close is a wrapper around g_variant_builder_close().
+func (v *VariantBuilder) Close() {
+	C.g_variant_builder_close(v.native())
+}
 
 /*
  * GVariantDict
@@ -325,4 +953,73 @@ func (v *VariantDict) Native() uintptr {
 	return uintptr(unsafe.Pointer(v.native()))
 }
 
+// VariantDictNew is a wrapper around g_variant_dict_new().  fromAsv may
+// be nil to create an empty dictionary, or a variant of type a{sv} whose
+// entries initially populate the dictionary.  A runtime finalizer is set
+// to release the dictionary.  GVariantDict requires GLib 2.40 or later.
+func VariantDictNew(fromAsv *Variant) (*VariantDict, error) {
+	if fromAsv != nil && fromAsv.TypeString() != "a{sv}" {
+		return nil, errors.New("fromAsv is not of type a{sv}")
+	}
+	c := C.g_variant_dict_new(fromAsv.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	d := newVariantDict(c)
+	runtime.SetFinalizer(d, (*VariantDict).Unref)
+	return d, nil
+}
+
+// Unref is a wrapper around g_variant_dict_unref().
+func (v *VariantDict) Unref() {
+	C.g_variant_dict_unref(v.native())
+}
+
+// Ref is a wrapper around g_variant_dict_ref().
+func (v *VariantDict) Ref() {
+	C.g_variant_dict_ref(v.native())
+}
 
+//void	g_variant_dict_init ()
+//void	g_variant_dict_clear ()
+
+// Contains is a wrapper around g_variant_dict_contains().
+func (v *VariantDict) Contains(key string) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	return gobool(C.g_variant_dict_contains(v.native(), (*C.gchar)(cstr)))
+}
+
+//gboolean	g_variant_dict_lookup ()
+
+// LookupValue is a wrapper around g_variant_dict_lookup_value().
+// expectedType may be nil to accept a value of any type.  nil is
+// returned if key is not present or its value does not match expectedType.
+func (v *VariantDict) LookupValue(key string, expectedType *VariantType) *Variant {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.g_variant_dict_lookup_value(v.native(), (*C.gchar)(cstr), expectedType.native())
+	return takeVariant(c)
+}
+
+//void	g_variant_dict_insert ()
+
+// InsertValue is a wrapper around g_variant_dict_insert_value().
+func (v *VariantDict) InsertValue(key string, value *Variant) {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_variant_dict_insert_value(v.native(), (*C.gchar)(cstr), value.native())
+}
+
+// Remove is a wrapper around g_variant_dict_remove().
+func (v *VariantDict) Remove(key string) bool {
+	cstr := C.CString(key)
+	defer C.free(unsafe.Pointer(cstr))
+	return gobool(C.g_variant_dict_remove(v.native(), (*C.gchar)(cstr)))
+}
+
+// End is a wrapper around g_variant_dict_end().  The returned variant is
+// of type a{sv} and the dictionary is left empty.
+func (v *VariantDict) End() *Variant {
+	return takeVariant(C.g_variant_dict_end(v.native()))
+}
@@ -0,0 +1,688 @@
+//GVariantMarshal : conversion between Go values and GVariants
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+var variantPtrType = reflect.TypeOf((*Variant)(nil))
+
+// VariantOf converts a Go value to a Variant, in the manner of
+// encoding/json's Marshal.  Go types are mapped to GVariant types as
+// follows:
+//
+//	bool                   b
+//	uint8                  y
+//	int16, uint16          n, q
+//	int32, uint32          i, u
+//	int64, int             x
+//	uint64, uint           t
+//	float32, float64       d
+//	string                 s
+//	[]byte, [N]byte        ay
+//	[]T, [N]T              aT
+//	map[K]T                a{KT}, K must map to a basic type
+//	*T                     mT, a nil pointer is Nothing
+//	struct                 a tuple of its exported fields
+//	*Variant, interface{}  v, when nested in a container
+//
+// A struct is instead encoded as an a{sv} dictionary if any of its fields
+// carries a `variant:"key"` tag naming its dictionary key.  Fields without
+// a tag are then keyed by their Go name.  The tag `variant:"-"` omits a
+// field in both encodings, and the option `variant:"key,omitempty"` omits
+// a zero-valued field from a dictionary.
+//
+// Passing a *Variant returns it unchanged.
+func VariantOf(value interface{}) (*Variant, error) {
+	if v, ok := value.(*Variant); ok {
+		if v == nil {
+			return nil, errors.New("cannot convert nil *Variant")
+		}
+		return v, nil
+	}
+	if value == nil {
+		return nil, errors.New("cannot convert nil to a Variant")
+	}
+	return encodeVariant(reflect.ValueOf(value))
+}
+
+// variantTypeString returns the GVariant type string a value of Go type t
+// is encoded as by VariantOf.
+func variantTypeString(t reflect.Type) (string, error) {
+	if t == variantPtrType {
+		return "v", nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "b", nil
+	case reflect.Uint8:
+		return "y", nil
+	case reflect.Int16:
+		return "n", nil
+	case reflect.Uint16:
+		return "q", nil
+	case reflect.Int32:
+		return "i", nil
+	case reflect.Uint32:
+		return "u", nil
+	case reflect.Int64, reflect.Int:
+		return "x", nil
+	case reflect.Uint64, reflect.Uint:
+		return "t", nil
+	case reflect.Float32, reflect.Float64:
+		return "d", nil
+	case reflect.String:
+		return "s", nil
+	case reflect.Interface:
+		return "v", nil
+
+	case reflect.Slice, reflect.Array:
+		elem, err := variantTypeString(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "a" + elem, nil
+
+	case reflect.Map:
+		key, err := variantTypeString(t.Key())
+		if err != nil {
+			return "", err
+		}
+		if !strings.Contains("bynqiuxtds", key) || len(key) != 1 {
+			return "", fmt.Errorf("map key type %s is not a basic type", t.Key())
+		}
+		elem, err := variantTypeString(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "a{" + key + elem + "}", nil
+
+	case reflect.Ptr:
+		elem, err := variantTypeString(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "m" + elem, nil
+
+	case reflect.Struct:
+		fields, dict := variantStructFields(t)
+		if dict {
+			return "a{sv}", nil
+		}
+		sig := "("
+		for _, f := range fields {
+			s, err := variantTypeString(t.Field(f.index).Type)
+			if err != nil {
+				return "", err
+			}
+			sig += s
+		}
+		return sig + ")", nil
+	}
+	return "", fmt.Errorf("Go type %s has no GVariant equivalent", t)
+}
+
+// variantField describes how a struct field is encoded in a Variant.
+type variantField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// variantStructFields returns the fields of struct type t which are
+// encoded in a Variant, and whether the struct is encoded as an a{sv}
+// dictionary rather than a tuple.
+func variantStructFields(t reflect.Type) (fields []variantField, dict bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		f := variantField{index: i, name: sf.Name}
+		if tag := sf.Tag.Get("variant"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			opts := strings.Split(tag, ",")
+			if opts[0] != "" {
+				f.name = opts[0]
+				dict = true
+			}
+			for _, opt := range opts[1:] {
+				if opt == "omitempty" {
+					f.omitEmpty = true
+				}
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, dict
+}
+
+// encodeVariant encodes rv as a Variant of the type given by
+// variantTypeString(rv.Type()).
+func encodeVariant(rv reflect.Value) (*Variant, error) {
+	if rv.Type() == variantPtrType {
+		if rv.IsNil() {
+			return nil, errors.New("cannot convert nil *Variant")
+		}
+		return VariantNewVariant(rv.Interface().(*Variant)), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return VariantNewBoolean(rv.Bool()), nil
+	case reflect.Uint8:
+		return VariantNewByte(uint8(rv.Uint())), nil
+	case reflect.Int16:
+		return VariantNewInt16(int16(rv.Int())), nil
+	case reflect.Uint16:
+		return VariantNewUint16(uint16(rv.Uint())), nil
+	case reflect.Int32:
+		return VariantNewInt32(int32(rv.Int())), nil
+	case reflect.Uint32:
+		return VariantNewUint32(uint32(rv.Uint())), nil
+	case reflect.Int64, reflect.Int:
+		return VariantNewInt64(rv.Int()), nil
+	case reflect.Uint64, reflect.Uint:
+		return VariantNewUint64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return VariantNewDouble(rv.Float()), nil
+	case reflect.String:
+		return VariantNewString(rv.String()), nil
+
+	case reflect.Interface:
+		if rv.IsNil() {
+			return nil, errors.New("cannot convert nil interface value")
+		}
+		if v, ok := rv.Interface().(*Variant); ok {
+			return encodeVariant(reflect.ValueOf(v))
+		}
+		inner, err := encodeVariant(rv.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return VariantNewVariant(inner), nil
+
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return encodeByteArray(rv), nil
+		}
+		children := make([]*Variant, rv.Len())
+		for i := range children {
+			child, err := encodeVariant(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			children[i] = child
+		}
+		return encodeArray(rv.Type().Elem(), children)
+
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return lessMapKey(keys[i], keys[j])
+		})
+		entries := make([]*Variant, len(keys))
+		for i, key := range keys {
+			k, err := encodeVariant(key)
+			if err != nil {
+				return nil, err
+			}
+			val, err := encodeVariant(rv.MapIndex(key))
+			if err != nil {
+				return nil, err
+			}
+			if entries[i], err = VariantNewDictEntry(k, val); err != nil {
+				return nil, err
+			}
+		}
+		sig, err := variantTypeString(rv.Type())
+		if err != nil {
+			return nil, err
+		}
+		entryType, err := VariantTypeNew(sig[1:])
+		if err != nil {
+			return nil, err
+		}
+		return VariantNewArray(entryType, entries...)
+
+	case reflect.Ptr:
+		sig, err := variantTypeString(rv.Type().Elem())
+		if err != nil {
+			return nil, err
+		}
+		childType, err := VariantTypeNew(sig)
+		if err != nil {
+			return nil, err
+		}
+		if rv.IsNil() {
+			return VariantNewMaybe(childType, nil)
+		}
+		child, err := encodeVariant(rv.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return VariantNewMaybe(childType, child)
+
+	case reflect.Struct:
+		fields, dict := variantStructFields(rv.Type())
+		if dict {
+			return encodeStructDict(rv, fields)
+		}
+		children := make([]*Variant, len(fields))
+		for i, f := range fields {
+			child, err := encodeVariant(rv.Field(f.index))
+			if err != nil {
+				return nil, err
+			}
+			children[i] = child
+		}
+		return VariantNewTuple(children...)
+	}
+	return nil, fmt.Errorf("Go type %s has no GVariant equivalent", rv.Type())
+}
+
+// encodeByteArray encodes a byte slice or array as a Variant of type ay.
+func encodeByteArray(rv reflect.Value) *Variant {
+	b := make([]byte, rv.Len())
+	reflect.Copy(reflect.ValueOf(b), rv)
+	var p unsafe.Pointer
+	if len(b) > 0 {
+		p = unsafe.Pointer(&b[0])
+	}
+	return takeVariant(C._g_variant_new_byte_array(C.gconstpointer(p), C.gsize(len(b))))
+}
+
+// encodeArray creates an array Variant holding children, which were
+// encoded from values of Go type elem.
+func encodeArray(elem reflect.Type, children []*Variant) (*Variant, error) {
+	sig, err := variantTypeString(elem)
+	if err != nil {
+		return nil, err
+	}
+	childType, err := VariantTypeNew(sig)
+	if err != nil {
+		return nil, err
+	}
+	return VariantNewArray(childType, children...)
+}
+
+// encodeStructDict encodes a struct as an a{sv} dictionary.
+func encodeStructDict(rv reflect.Value, fields []variantField) (*Variant, error) {
+	d, err := VariantDictNew(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		fv := rv.Field(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		val, err := encodeVariant(fv)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", f.name, err)
+		}
+		d.InsertValue(f.name, val)
+	}
+	return d.End(), nil
+}
+
+// lessMapKey orders map keys so that encoded dictionaries are
+// deterministic.
+func lessMapKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	}
+	return false
+}
+
+// Unmarshal stores the contents of v in the Go value pointed to by dst, in
+// the manner of encoding/json's Unmarshal.  The type mapping is that of
+// VariantOf, with these relaxations:
+//
+// Any integer variant may be stored in any Go integer type that can hold
+// its value.  Variants of type v are unboxed unless dst is a **Variant.
+// Tuples may be stored in slices.  A Nothing maybe value sets a pointer to
+// nil.  Dictionaries may be stored in structs, matching keys to field tags
+// or names as described for VariantOf, and keys with no matching field
+// are ignored.  Storing into an empty interface stores the value returned
+// by v.GoValue().
+//
+// A non-nil error is returned if the type of v cannot be stored in dst.
+func (v *Variant) Unmarshal(dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("Unmarshal destination must be a non-nil pointer")
+	}
+	if v == nil {
+		return errors.New("cannot unmarshal nil *Variant")
+	}
+	return v.decode(rv.Elem())
+}
+
+func (v *Variant) decodeError(rv reflect.Value) error {
+	return fmt.Errorf("cannot unmarshal variant of type %s into Go value of type %s",
+		v.TypeString(), rv.Type())
+}
+
+// decode stores v in the settable value rv.
+func (v *Variant) decode(rv reflect.Value) error {
+	if rv.Type() == variantPtrType {
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	class := v.Classify()
+	if rv.Kind() == reflect.Interface {
+		if rv.NumMethod() != 0 {
+			return v.decodeError(rv)
+		}
+		val := v.GoValue()
+		if val != nil {
+			rv.Set(reflect.ValueOf(val))
+		} else {
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+		return nil
+	}
+	if class == VARIANT_CLASS_VARIANT {
+		return v.GetVariant().decode(rv)
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		if class != VARIANT_CLASS_BOOLEAN {
+			return v.decodeError(rv)
+		}
+		rv.SetBool(v.GetBoolean())
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, u, signed, ok := v.integer()
+		if !ok {
+			return v.decodeError(rv)
+		}
+		if !signed {
+			if u > 1<<63-1 {
+				return fmt.Errorf("variant value %d overflows %s", u, rv.Type())
+			}
+			i = int64(u)
+		}
+		if rv.OverflowInt(i) {
+			return fmt.Errorf("variant value %d overflows %s", i, rv.Type())
+		}
+		rv.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, u, signed, ok := v.integer()
+		if !ok {
+			return v.decodeError(rv)
+		}
+		if signed {
+			if i < 0 {
+				return fmt.Errorf("variant value %d overflows %s", i, rv.Type())
+			}
+			u = uint64(i)
+		}
+		if rv.OverflowUint(u) {
+			return fmt.Errorf("variant value %d overflows %s", u, rv.Type())
+		}
+		rv.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		if class != VARIANT_CLASS_DOUBLE {
+			return v.decodeError(rv)
+		}
+		rv.SetFloat(v.GetDouble())
+		return nil
+
+	case reflect.String:
+		switch class {
+		case VARIANT_CLASS_STRING, VARIANT_CLASS_OBJECT_PATH, VARIANT_CLASS_SIGNATURE:
+			rv.SetString(v.GetString())
+			return nil
+		}
+		return v.decodeError(rv)
+
+	case reflect.Ptr:
+		if class == VARIANT_CLASS_MAYBE {
+			child := v.GetMaybe()
+			if child == nil {
+				rv.Set(reflect.Zero(rv.Type()))
+				return nil
+			}
+			v = child
+		}
+		p := reflect.New(rv.Type().Elem())
+		if err := v.decode(p.Elem()); err != nil {
+			return err
+		}
+		rv.Set(p)
+		return nil
+
+	case reflect.Slice:
+		if class != VARIANT_CLASS_ARRAY && class != VARIANT_CLASS_TUPLE {
+			return v.decodeError(rv)
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 && v.TypeString() == "ay" {
+			b := v.byteArray()
+			s := reflect.MakeSlice(rv.Type(), len(b), len(b))
+			reflect.Copy(s, reflect.ValueOf(b))
+			rv.Set(s)
+			return nil
+		}
+		n := int(v.NChildren())
+		s := reflect.MakeSlice(rv.Type(), n, n)
+		if err := v.decodeChildren(s); err != nil {
+			return err
+		}
+		rv.Set(s)
+		return nil
+
+	case reflect.Array:
+		if class != VARIANT_CLASS_ARRAY && class != VARIANT_CLASS_TUPLE {
+			return v.decodeError(rv)
+		}
+		if int(v.NChildren()) != rv.Len() {
+			return fmt.Errorf("cannot unmarshal %d elements into Go value of type %s",
+				v.NChildren(), rv.Type())
+		}
+		return v.decodeChildren(rv)
+
+	case reflect.Map:
+		if class != VARIANT_CLASS_ARRAY || !strings.HasPrefix(v.TypeString(), "a{") {
+			return v.decodeError(rv)
+		}
+		m := reflect.MakeMap(rv.Type())
+		for i := uint(0); i < v.NChildren(); i++ {
+			entry, _ := v.GetChildValue(i)
+			k, _ := entry.GetChildValue(0)
+			val, _ := entry.GetChildValue(1)
+			kv := reflect.New(rv.Type().Key()).Elem()
+			if err := k.decode(kv); err != nil {
+				return err
+			}
+			vv := reflect.New(rv.Type().Elem()).Elem()
+			if err := val.decode(vv); err != nil {
+				return err
+			}
+			m.SetMapIndex(kv, vv)
+		}
+		rv.Set(m)
+		return nil
+
+	case reflect.Struct:
+		fields, _ := variantStructFields(rv.Type())
+		if class == VARIANT_CLASS_TUPLE {
+			if int(v.NChildren()) != len(fields) {
+				return v.decodeError(rv)
+			}
+			for i, f := range fields {
+				child, _ := v.GetChildValue(uint(i))
+				if err := child.decode(rv.Field(f.index)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if class != VARIANT_CLASS_ARRAY || !strings.HasPrefix(v.TypeString(), "a{s") {
+			return v.decodeError(rv)
+		}
+		for _, f := range fields {
+			val := v.LookupValue(f.name, nil)
+			if val == nil {
+				continue
+			}
+			if err := val.decode(rv.Field(f.index)); err != nil {
+				return fmt.Errorf("field %s: %v", f.name, err)
+			}
+		}
+		return nil
+	}
+	return v.decodeError(rv)
+}
+
+// decodeChildren stores each child of v in the corresponding element of
+// the slice or array rv.
+func (v *Variant) decodeChildren(rv reflect.Value) error {
+	for i := 0; i < rv.Len(); i++ {
+		child, err := v.GetChildValue(uint(i))
+		if err != nil {
+			return err
+		}
+		if err := child.decode(rv.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// integer returns the value of an integer variant.  If signed is true, the
+// value is held in i, otherwise in u.  ok is false if v is not an integer.
+func (v *Variant) integer() (i int64, u uint64, signed, ok bool) {
+	switch v.Classify() {
+	case VARIANT_CLASS_BYTE:
+		return 0, uint64(v.GetByte()), false, true
+	case VARIANT_CLASS_INT16:
+		return int64(v.GetInt16()), 0, true, true
+	case VARIANT_CLASS_UINT16:
+		return 0, uint64(v.GetUint16()), false, true
+	case VARIANT_CLASS_INT32:
+		return int64(v.GetInt32()), 0, true, true
+	case VARIANT_CLASS_HANDLE:
+		return int64(v.GetHandle()), 0, true, true
+	case VARIANT_CLASS_UINT32:
+		return 0, uint64(v.GetUint32()), false, true
+	case VARIANT_CLASS_INT64:
+		return v.GetInt64(), 0, true, true
+	case VARIANT_CLASS_UINT64:
+		return 0, v.GetUint64(), false, true
+	}
+	return 0, 0, false, false
+}
+
+// byteArray returns the contents of a variant of type ay.
+func (v *Variant) byteArray() []byte {
+	var n C.gsize
+	p := C.g_variant_get_fixed_array(v.native(), &n, 1)
+	if n == 0 {
+		return []byte{}
+	}
+	return C.GoBytes(unsafe.Pointer(p), C.int(n))
+}
+
+// GoValue converts v to its natural Go representation: basic types map
+// to the Go types listed for VariantOf (with int64 and uint64 for x and t,
+// and string for o and g), v is unboxed, a Nothing maybe value is nil,
+// ay is a []byte, as is a []string, dictionaries with string keys are a
+// map[string]interface{}, other dictionaries are a
+// map[interface{}]interface{}, and other arrays and tuples are an
+// []interface{}.
+func (v *Variant) GoValue() interface{} {
+	switch v.Classify() {
+	case VARIANT_CLASS_BOOLEAN:
+		return v.GetBoolean()
+	case VARIANT_CLASS_BYTE:
+		return v.GetByte()
+	case VARIANT_CLASS_INT16:
+		return v.GetInt16()
+	case VARIANT_CLASS_UINT16:
+		return v.GetUint16()
+	case VARIANT_CLASS_INT32:
+		return v.GetInt32()
+	case VARIANT_CLASS_UINT32:
+		return v.GetUint32()
+	case VARIANT_CLASS_INT64:
+		return v.GetInt64()
+	case VARIANT_CLASS_UINT64:
+		return v.GetUint64()
+	case VARIANT_CLASS_HANDLE:
+		return v.GetHandle()
+	case VARIANT_CLASS_DOUBLE:
+		return v.GetDouble()
+	case VARIANT_CLASS_STRING, VARIANT_CLASS_OBJECT_PATH, VARIANT_CLASS_SIGNATURE:
+		return v.GetString()
+	case VARIANT_CLASS_VARIANT:
+		return v.GetVariant().GoValue()
+	case VARIANT_CLASS_MAYBE:
+		if child := v.GetMaybe(); child != nil {
+			return child.GoValue()
+		}
+		return nil
+	}
+
+	sig := v.TypeString()
+	switch {
+	case sig == "ay":
+		return v.byteArray()
+	case sig == "as":
+		return v.GetStrv()
+	case strings.HasPrefix(sig, "a{s"):
+		m := make(map[string]interface{}, v.NChildren())
+		for i := uint(0); i < v.NChildren(); i++ {
+			entry, _ := v.GetChildValue(i)
+			k, _ := entry.GetChildValue(0)
+			val, _ := entry.GetChildValue(1)
+			m[k.GetString()] = val.GoValue()
+		}
+		return m
+	case strings.HasPrefix(sig, "a{"):
+		m := make(map[interface{}]interface{}, v.NChildren())
+		for i := uint(0); i < v.NChildren(); i++ {
+			entry, _ := v.GetChildValue(i)
+			k, _ := entry.GetChildValue(0)
+			val, _ := entry.GetChildValue(1)
+			m[k.GoValue()] = val.GoValue()
+		}
+		return m
+	}
+
+	s := make([]interface{}, v.NChildren())
+	for i := range s {
+		child, _ := v.GetChildValue(uint(i))
+		s[i] = child.GoValue()
+	}
+	return s
+}
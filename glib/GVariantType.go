@@ -6,7 +6,11 @@ package glib
 // #include <glib-object.h>
 // #include "glib.go.h"
 import "C"
-import "unsafe"
+import (
+	"errors"
+	"runtime"
+	"unsafe"
+)
 
 /*
  * GVariantType
@@ -23,6 +27,11 @@ type IVariantType interface {
 // VariantType is a representation of GLib's GVariantType.
 type VariantType struct {
 	GVariantType *C.GVariantType
+
+	// owner keeps alive the value which owns the memory of a borrowed
+	// GVariantType, such as the Variant it was taken from or the
+	// container type it is an item of.
+	owner interface{}
 }
 
 func (v *VariantType) toGVariantType() *C.GVariantType {
@@ -41,8 +50,30 @@ func newVariantType(p *C.GVariantType) *VariantType {
 	return &VariantType{GVariantType: p}
 }
 
-func VariantTypeFromUnsafePointer(p unsafe.Pointer) *VariantType{
-	return &VariantType{ C.toGVariantType(p) }
+// takeVariantType creates a new VariantType from a GVariantType pointer
+// owned by the caller, and sets a runtime finalizer to free it.
+// takeVariantType returns nil if p is nil.
+func takeVariantType(p *C.GVariantType) *VariantType {
+	if p == nil {
+		return nil
+	}
+	t := newVariantType(p)
+	runtime.SetFinalizer(t, (*VariantType).free)
+	return t
+}
+
+// borrowVariantType creates a new VariantType from a GVariantType pointer
+// whose memory is owned by owner.  borrowVariantType returns nil if p is
+// nil.
+func borrowVariantType(p *C.GVariantType, owner interface{}) *VariantType {
+	if p == nil {
+		return nil
+	}
+	return &VariantType{GVariantType: p, owner: owner}
+}
+
+func VariantTypeFromUnsafePointer(p unsafe.Pointer) *VariantType {
+	return &VariantType{GVariantType: C.toGVariantType(p)}
 }
 
 // native returns a pointer to the underlying GVariantType.
@@ -59,61 +90,216 @@ func (v *VariantType) Native() uintptr {
 	return uintptr(unsafe.Pointer(v.native()))
 }
 
+func (v *VariantType) free() {
+	C.g_variant_type_free(v.native())
+}
+
+// variantTypeStatic creates a VariantType which is never freed from a
+// type string known to be valid.
+func variantTypeStatic(s string) *VariantType {
+	cstr := C.CString(s)
+	defer C.free(unsafe.Pointer(cstr))
+	return newVariantType(C.g_variant_type_new((*C.gchar)(cstr)))
+}
+
+var (
+	VARIANT_TYPE_BOOLEAN           = variantTypeStatic("b")
+	VARIANT_TYPE_BYTE              = variantTypeStatic("y")
+	VARIANT_TYPE_INT16             = variantTypeStatic("n")
+	VARIANT_TYPE_UINT16            = variantTypeStatic("q")
+	VARIANT_TYPE_INT32             = variantTypeStatic("i")
+	VARIANT_TYPE_UINT32            = variantTypeStatic("u")
+	VARIANT_TYPE_INT64             = variantTypeStatic("x")
+	VARIANT_TYPE_UINT64            = variantTypeStatic("t")
+	VARIANT_TYPE_HANDLE            = variantTypeStatic("h")
+	VARIANT_TYPE_DOUBLE            = variantTypeStatic("d")
+	VARIANT_TYPE_STRING            = variantTypeStatic("s")
+	VARIANT_TYPE_OBJECT_PATH       = variantTypeStatic("o")
+	VARIANT_TYPE_SIGNATURE         = variantTypeStatic("g")
+	VARIANT_TYPE_VARIANT           = variantTypeStatic("v")
+	VARIANT_TYPE_ANY               = variantTypeStatic("*")
+	VARIANT_TYPE_BASIC             = variantTypeStatic("?")
+	VARIANT_TYPE_MAYBE             = variantTypeStatic("m*")
+	VARIANT_TYPE_ARRAY             = variantTypeStatic("a*")
+	VARIANT_TYPE_TUPLE             = variantTypeStatic("r")
+	VARIANT_TYPE_UNIT              = variantTypeStatic("()")
+	VARIANT_TYPE_DICT_ENTRY        = variantTypeStatic("{?*}")
+	VARIANT_TYPE_DICTIONARY        = variantTypeStatic("a{?*}")
+	VARIANT_TYPE_STRING_ARRAY      = variantTypeStatic("as")
+	VARIANT_TYPE_OBJECT_PATH_ARRAY = variantTypeStatic("ao")
+	VARIANT_TYPE_BYTESTRING        = variantTypeStatic("ay")
+	VARIANT_TYPE_BYTESTRING_ARRAY  = variantTypeStatic("aay")
+	VARIANT_TYPE_VARDICT           = variantTypeStatic("a{sv}")
+)
+
+// VariantTypeNew is a wrapper around g_variant_type_new().  A non-nil
+// error is returned if typeString is not a valid GVariant type string.
+func VariantTypeNew(typeString string) (*VariantType, error) {
+	cstr := C.CString(typeString)
+	defer C.free(unsafe.Pointer(cstr))
+	if !gobool(C.g_variant_type_string_is_valid((*C.gchar)(cstr))) {
+		return nil, errors.New("invalid variant type string: " + typeString)
+	}
+	return takeVariantType(C.g_variant_type_new((*C.gchar)(cstr))), nil
+}
+
+// Copy is a wrapper around g_variant_type_copy().
+func (v *VariantType) Copy() *VariantType {
+	return takeVariantType(C.g_variant_type_copy(v.native()))
+}
+
+// VariantTypeStringIsValid is a wrapper around
+// g_variant_type_string_is_valid().
+func VariantTypeStringIsValid(typeString string) bool {
+	cstr := C.CString(typeString)
+	defer C.free(unsafe.Pointer(cstr))
+	return gobool(C.g_variant_type_string_is_valid((*C.gchar)(cstr)))
+}
 
-//#define	G_VARIANT_TYPE_BOOLEAN
-//#define	G_VARIANT_TYPE_BYTE
-//#define	G_VARIANT_TYPE_INT16
-//#define	G_VARIANT_TYPE_UINT16
-//#define	G_VARIANT_TYPE_INT32
-//#define	G_VARIANT_TYPE_UINT32
-//#define	G_VARIANT_TYPE_INT64
-//#define	G_VARIANT_TYPE_UINT64
-//#define	G_VARIANT_TYPE_HANDLE
-//#define	G_VARIANT_TYPE_DOUBLE
-//#define	G_VARIANT_TYPE_STRING
-//#define	G_VARIANT_TYPE_OBJECT_PATH
-//#define	G_VARIANT_TYPE_SIGNATURE
-//#define	G_VARIANT_TYPE_VARIANT
-//#define	G_VARIANT_TYPE_ANY
-//#define	G_VARIANT_TYPE_BASIC
-//#define	G_VARIANT_TYPE_MAYBE
-//#define	G_VARIANT_TYPE_ARRAY
-//#define	G_VARIANT_TYPE_TUPLE
-//#define	G_VARIANT_TYPE_UNIT
-//#define	G_VARIANT_TYPE_DICT_ENTRY
-//#define	G_VARIANT_TYPE_DICTIONARY
-//#define	G_VARIANT_TYPE_STRING_ARRAY
-//#define	G_VARIANT_TYPE_OBJECT_PATH_ARRAY
-//#define	G_VARIANT_TYPE_BYTESTRING
-//#define	G_VARIANT_TYPE_BYTESTRING_ARRAY
-//#define	G_VARIANT_TYPE_VARDICT
-//#define	G_VARIANT_TYPE()
-//void	g_variant_type_free ()
-//GVariantType *	g_variant_type_copy ()
-//GVariantType *	g_variant_type_new ()
-//gboolean	g_variant_type_string_is_valid ()
 //gboolean	g_variant_type_string_scan ()
 //gsize	g_variant_type_get_string_length ()
 //const gchar *	g_variant_type_peek_string ()
-//gchar *	g_variant_type_dup_string ()
-//gboolean	g_variant_type_is_definite ()
-//gboolean	g_variant_type_is_container ()
-//gboolean	g_variant_type_is_basic ()
-//gboolean	g_variant_type_is_maybe ()
-//gboolean	g_variant_type_is_array ()
-//gboolean	g_variant_type_is_tuple ()
-//gboolean	g_variant_type_is_dict_entry ()
-//gboolean	g_variant_type_is_variant ()
-//guint	g_variant_type_hash ()
-//gboolean	g_variant_type_equal ()
-//gboolean	g_variant_type_is_subtype_of ()
-//GVariantType *	g_variant_type_new_maybe ()
-//GVariantType *	g_variant_type_new_array ()
-//GVariantType *	g_variant_type_new_tuple ()
-//GVariantType *	g_variant_type_new_dict_entry ()
-//const GVariantType *	g_variant_type_element ()
-//gsize	g_variant_type_n_items ()
-//const GVariantType *	g_variant_type_first ()
-//const GVariantType *	g_variant_type_next ()
-//const GVariantType *	g_variant_type_key ()
-//const GVariantType *	g_variant_type_value ()
+
+// String is a wrapper around g_variant_type_dup_string().
+func (v *VariantType) String() string {
+	c := C.g_variant_type_dup_string(v.native())
+	defer C.g_free(C.gpointer(c))
+	return C.GoString((*C.char)(c))
+}
+
+// IsDefinite is a wrapper around g_variant_type_is_definite().
+func (v *VariantType) IsDefinite() bool {
+	return gobool(C.g_variant_type_is_definite(v.native()))
+}
+
+// IsContainer is a wrapper around g_variant_type_is_container().
+func (v *VariantType) IsContainer() bool {
+	return gobool(C.g_variant_type_is_container(v.native()))
+}
+
+// IsBasic is a wrapper around g_variant_type_is_basic().
+func (v *VariantType) IsBasic() bool {
+	return gobool(C.g_variant_type_is_basic(v.native()))
+}
+
+// IsMaybe is a wrapper around g_variant_type_is_maybe().
+func (v *VariantType) IsMaybe() bool {
+	return gobool(C.g_variant_type_is_maybe(v.native()))
+}
+
+// IsArray is a wrapper around g_variant_type_is_array().
+func (v *VariantType) IsArray() bool {
+	return gobool(C.g_variant_type_is_array(v.native()))
+}
+
+// IsTuple is a wrapper around g_variant_type_is_tuple().
+func (v *VariantType) IsTuple() bool {
+	return gobool(C.g_variant_type_is_tuple(v.native()))
+}
+
+// IsDictEntry is a wrapper around g_variant_type_is_dict_entry().
+func (v *VariantType) IsDictEntry() bool {
+	return gobool(C.g_variant_type_is_dict_entry(v.native()))
+}
+
+// IsVariant is a wrapper around g_variant_type_is_variant().
+func (v *VariantType) IsVariant() bool {
+	return gobool(C.g_variant_type_is_variant(v.native()))
+}
+
+// Hash is a wrapper around g_variant_type_hash().
+func (v *VariantType) Hash() uint {
+	return uint(C.g_variant_type_hash(C.gconstpointer(unsafe.Pointer(v.native()))))
+}
+
+// Equal is a wrapper around g_variant_type_equal().
+func (v *VariantType) Equal(other *VariantType) bool {
+	c := C.g_variant_type_equal(C.gconstpointer(unsafe.Pointer(v.native())),
+		C.gconstpointer(unsafe.Pointer(other.native())))
+	return gobool(c)
+}
+
+// IsSubtypeOf is a wrapper around g_variant_type_is_subtype_of().
+func (v *VariantType) IsSubtypeOf(supertype *VariantType) bool {
+	return gobool(C.g_variant_type_is_subtype_of(v.native(), supertype.native()))
+}
+
+// VariantTypeMaybe is a wrapper around g_variant_type_new_maybe().
+func VariantTypeMaybe(element *VariantType) *VariantType {
+	return takeVariantType(C.g_variant_type_new_maybe(element.native()))
+}
+
+// VariantTypeArray is a wrapper around g_variant_type_new_array().
+func VariantTypeArray(element *VariantType) *VariantType {
+	return takeVariantType(C.g_variant_type_new_array(element.native()))
+}
+
+// VariantTypeTuple is a wrapper around g_variant_type_new_tuple().
+func VariantTypeTuple(items ...*VariantType) *VariantType {
+	list := C.alloc_variant_type_list(C.int(len(items)))
+	defer C.g_free(C.gpointer(list))
+	for i := range items {
+		C.variant_type_list_insert(list, C.int(i), items[i].native())
+	}
+	return takeVariantType(C.g_variant_type_new_tuple(list, C.gint(len(items))))
+}
+
+// VariantTypeDictEntry is a wrapper around g_variant_type_new_dict_entry().
+// A non-nil error is returned if key is not a basic type.
+func VariantTypeDictEntry(key, value *VariantType) (*VariantType, error) {
+	if !key.IsBasic() {
+		return nil, errors.New("dict entry key must be a basic type")
+	}
+	return takeVariantType(C.g_variant_type_new_dict_entry(key.native(), value.native())), nil
+}
+
+// Element is a wrapper around g_variant_type_element().  nil is returned
+// if v is not an array or maybe type.
+func (v *VariantType) Element() *VariantType {
+	if !v.IsArray() && !v.IsMaybe() {
+		return nil
+	}
+	return borrowVariantType(C.g_variant_type_element(v.native()), v)
+}
+
+// NItems is a wrapper around g_variant_type_n_items().  0 is returned if v
+// is not a tuple or dict entry type.
+func (v *VariantType) NItems() uint {
+	if !v.IsTuple() && !v.IsDictEntry() {
+		return 0
+	}
+	return uint(C.g_variant_type_n_items(v.native()))
+}
+
+// First is a wrapper around g_variant_type_first().  nil is returned if v
+// is not a tuple or dict entry type, or is the unit tuple type.
+func (v *VariantType) First() *VariantType {
+	if !v.IsTuple() && !v.IsDictEntry() {
+		return nil
+	}
+	return borrowVariantType(C.g_variant_type_first(v.native()), v)
+}
+
+// Next is a wrapper around g_variant_type_next().  v must have been
+// returned by First or Next.  nil is returned after the last item.
+func (v *VariantType) Next() *VariantType {
+	return borrowVariantType(C.g_variant_type_next(v.native()), v.owner)
+}
+
+// Key is a wrapper around g_variant_type_key().  nil is returned if v is
+// not a dict entry type.
+func (v *VariantType) Key() *VariantType {
+	if !v.IsDictEntry() {
+		return nil
+	}
+	return borrowVariantType(C.g_variant_type_key(v.native()), v)
+}
+
+// Value is a wrapper around g_variant_type_value().  nil is returned if v
+// is not a dict entry type.
+func (v *VariantType) Value() *VariantType {
+	if !v.IsDictEntry() {
+		return nil
+	}
+	return borrowVariantType(C.g_variant_type_value(v.native()), v)
+}
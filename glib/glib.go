@@ -50,6 +50,40 @@ func gobool(b C.gboolean) bool {
 	return false
 }
 
+// cStrv allocates a NULL-terminated C string array holding copies of
+// strs.  The array must be released with freeStrv.
+func cStrv(strs []string) **C.gchar {
+	strv := C.alloc_strv(C.int(len(strs)))
+	for i := range strs {
+		C.strv_set(strv, C.int(i), (*C.gchar)(C.CString(strs[i])))
+	}
+	return strv
+}
+
+// freeStrv releases a string array allocated by cStrv.
+func freeStrv(strv **C.gchar, n int) {
+	for i := 0; i < n; i++ {
+		C.free(unsafe.Pointer(C.strv_get(strv, C.int(i))))
+	}
+	C.g_free(C.gpointer(strv))
+}
+
+// goStrv copies the first n strings of a C string array into a Go slice.
+// If n is negative, the array is read up to its NULL terminator.
+func goStrv(strv **C.gchar, n int) []string {
+	if strv == nil {
+		return nil
+	}
+	if n < 0 {
+		n = int(C.g_strv_length(strv))
+	}
+	strs := make([]string, n)
+	for i := range strs {
+		strs[i] = C.GoString((*C.char)(C.strv_get(strv, C.int(i))))
+	}
+	return strs
+}
+
 /*
  * Unexported vars
  */
@@ -57,6 +91,16 @@ func gobool(b C.gboolean) bool {
 type closureContext struct {
 	rf       reflect.Value
 	userData reflect.Value
+	once     bool
+	swapped  bool
+	source   bool
+}
+
+// signalKey identifies a connected signal handler.  Handler ids are only
+// unique for each instance.
+type signalKey struct {
+	instance *C.GObject
+	handle   SignalHandle
 }
 
 var (
@@ -69,7 +113,16 @@ var (
 		m: make(map[*C.GClosure]closureContext),
 	}
 
-	signals = make(map[SignalHandle]*C.GClosure)
+	// signals maps connected handlers to their closures, and back, so
+	// that entries are removed when the closure is finalized.
+	signals = struct {
+		sync.Mutex
+		m         map[signalKey]*C.GClosure
+		byClosure map[*C.GClosure]signalKey
+	}{
+		m:         make(map[signalKey]*C.GClosure),
+		byClosure: make(map[*C.GClosure]signalKey),
+	}
 )
 
 /*
@@ -135,6 +188,18 @@ const (
 
 const USER_N_DIRECTORIES int = C.G_USER_N_DIRECTORIES
 
+// IOCondition is a representation of GLib's GIOCondition.
+type IOCondition int
+
+const (
+	IO_IN   IOCondition = C.G_IO_IN
+	IO_OUT  IOCondition = C.G_IO_OUT
+	IO_PRI  IOCondition = C.G_IO_PRI
+	IO_ERR  IOCondition = C.G_IO_ERR
+	IO_HUP  IOCondition = C.G_IO_HUP
+	IO_NVAL IOCondition = C.G_IO_NVAL
+)
+
 /*
  * Events
  */
@@ -149,13 +214,73 @@ type SignalHandle uint
 //
 // Arguments for f must be a matching Go equivalent type for the
 // C callback, or an interface type which the value may be packed in.
-// If the type is not suitable, a runtime panic will occur when the
-// signal is emitted.
+// The parameter and return types of f are checked against those of the
+// signal, as reported by g_signal_query(), and a non-nil error is returned
+// if they are not suitable.  The Go types of objects and boxed values
+// are not known until the signal is emitted, so only their kind is
+// checked.
 func (v *Object) Connect(detailedSignal string, f interface{}, userData ...interface{}) (SignalHandle, error) {
+	return v.connectClosure(detailedSignal, f, nil, closureContext{}, false, userData)
+}
+
+// ConnectAfter is a wrapper around g_signal_connect_closure() with after
+// set to true, so that f runs after the default handler of the signal.
+// It is otherwise identical to Connect.
+func (v *Object) ConnectAfter(detailedSignal string, f interface{}, userData ...interface{}) (SignalHandle, error) {
+	return v.connectClosure(detailedSignal, f, nil, closureContext{}, true, userData)
+}
+
+// ConnectOnce connects f like Connect, but disconnects it the first time
+// the signal is emitted, before f runs.
+func (v *Object) ConnectOnce(detailedSignal string, f interface{}, userData ...interface{}) (SignalHandle, error) {
+	return v.connectClosure(detailedSignal, f, nil, closureContext{once: true}, false, userData)
+}
+
+// ConnectSwapped connects f like Connect, but passes userData as the
+// first argument of f and the instance as the last, as
+// G_CONNECT_SWAPPED does.
+func (v *Object) ConnectSwapped(detailedSignal string, f interface{}, userData ...interface{}) (SignalHandle, error) {
+	return v.connectClosure(detailedSignal, f, nil, closureContext{swapped: true}, false, userData)
+}
+
+// ConnectObject connects f like Connect, but ties the lifetime of the
+// handler to gobject, as g_signal_connect_object() does: the handler is
+// disconnected when gobject is finalized.  This allows handlers on
+// long-lived objects to refer to short-lived ones without keeping them
+// connected forever.  If after is true, f runs after the default handler.
+func (v *Object) ConnectObject(detailedSignal string, f interface{}, gobject IObject, after bool, userData ...interface{}) (SignalHandle, error) {
+	if gobject == nil {
+		return 0, errors.New("gobject must not be nil")
+	}
+	return v.connectClosure(detailedSignal, f, gobject, closureContext{}, after, userData)
+}
+
+// connectClosure connects f to detailedSignal using a closure created
+// with the options of cc.  If gobject is not nil, the closure is
+// invalidated, and so disconnected, when gobject is finalized.
+func (v *Object) connectClosure(detailedSignal string, f interface{}, gobject IObject,
+	cc closureContext, after bool, userData []interface{}) (SignalHandle, error) {
+
 	if len(userData) > 1 {
 		return 0, errors.New("userData len must be 0 or 1")
 	}
 
+	rf := reflect.ValueOf(f)
+	if rf.Kind() != reflect.Func {
+		return 0, errors.New("value is not a func")
+	}
+	info, err := signalQueryDetailed(detailedSignal, v.TypeFromInstance())
+	if err != nil {
+		return 0, err
+	}
+	var rUserData reflect.Value
+	if len(userData) > 0 {
+		rUserData = reflect.ValueOf(userData[0])
+	}
+	if err := checkSignalFunc(info, rf, rUserData, cc.swapped); err != nil {
+		return 0, err
+	}
+
 	cstr := C.CString(detailedSignal)
 	defer C.free(unsafe.Pointer(cstr))
 
@@ -163,15 +288,33 @@ func (v *Object) Connect(detailedSignal string, f interface{}, userData ...inter
 	if err != nil {
 		return 0, err
 	}
+	if cc.once || cc.swapped {
+		closures.Lock()
+		ctx := closures.m[closure]
+		ctx.once, ctx.swapped = cc.once, cc.swapped
+		closures.m[closure] = ctx
+		closures.Unlock()
+	}
 
 	C._g_closure_add_finalize_notifier(closure)
+	if gobject != nil {
+		C.g_object_watch_closure(gobject.toGObject(), closure)
+	}
 
 	c := C.g_signal_connect_closure(C.gpointer(v.native()),
-		(*C.gchar)(cstr), closure, gbool(false))
+		(*C.gchar)(cstr), closure, gbool(after))
+	if c == 0 {
+		C.g_closure_sink(closure)
+		return 0, errors.New("unable to connect to signal " + detailedSignal)
+	}
 	handle := SignalHandle(c)
 
 	// Map the signal handle to the closure.
-	signals[handle] = closure
+	key := signalKey{v.native(), handle}
+	signals.Lock()
+	signals.m[key] = closure
+	signals.byClosure[closure] = key
+	signals.Unlock()
 
 	return handle, nil
 }
@@ -217,6 +360,48 @@ func removeClosure(_ C.gpointer, closure *C.GClosure) {
 	closures.Lock()
 	delete(closures.m, closure)
 	closures.Unlock()
+
+	signals.Lock()
+	if key, ok := signals.byClosure[closure]; ok {
+		delete(signals.m, key)
+		delete(signals.byClosure, closure)
+	}
+	signals.Unlock()
+}
+
+// Stats holds counts of the Go callbacks currently referenced by GLib.
+// Numbers growing over the lifetime of a program usually mean handlers
+// or sources are leaked.
+type Stats struct {
+	// Closures is the number of live closures calling Go funcs,
+	// including those of signal handlers and sources.
+	Closures int
+
+	// SignalHandlers is the number of connected signal handlers.
+	SignalHandlers int
+
+	// Sources is the number of event sources calling Go funcs which
+	// have not yet been removed.
+	Sources int
+}
+
+// ReadStats returns the current Stats.
+func ReadStats() Stats {
+	var stats Stats
+
+	closures.RLock()
+	stats.Closures = len(closures.m)
+	for _, cc := range closures.m {
+		if cc.source {
+			stats.Sources++
+		}
+	}
+	closures.RUnlock()
+
+	signals.Lock()
+	stats.SignalHandlers = len(signals.m)
+	signals.Unlock()
+	return stats
 }
 
 // goMarshal is called by the GLib runtime when a closure needs to be invoked.
@@ -254,22 +439,53 @@ func goMarshal(closure *C.GClosure, retValue *C.GValue,
 		return
 	}
 
-	// Create a slice of reflect.Values as arguments to call the function.
-	gValues := gValueSlice(params, nCbParams)
+	// Swapped closures receive the user data first and the instance
+	// last, with the remaining parameters in between.
+	var args []reflect.Value
+	var err error
+	if cc.swapped {
+		args, err = swappedArgs(cc, gValueSlice(params, nGLibParams), nCbParams)
+	} else {
+		args, err = closureArgs(cc, gValueSlice(params, nGLibParams), nCbParams)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	// Handlers connected with ConnectOnce are disconnected before they
+	// run, so that they are not run again by a nested emission.
+	if cc.once && nGLibParams > 0 {
+		C.g_signal_handlers_disconnect_matched(C.g_value_peek_pointer(params),
+			C.G_SIGNAL_MATCH_CLOSURE, 0, 0, closure, nil, nil)
+	}
+
+	// Call closure with args. If the callback returns one or more
+	// values, save the GValue equivalent of the first.
+	rv := cc.rf.Call(args)
+	if retValue != nil && len(rv) > 0 {
+		if g, err := GValue(rv[0].Interface()); err != nil {
+			fmt.Fprintf(os.Stderr,
+				"cannot save callback return value: %v", err)
+		} else {
+			*retValue = *g.native()
+		}
+	}
+}
+
+// closureArgs converts the parameters of a closure invocation, followed
+// by the user data of cc, to the first nCbParams arguments of cc.rf.
+func closureArgs(cc closureContext, gValues []C.GValue, nCbParams int) ([]reflect.Value, error) {
 	args := make([]reflect.Value, 0, nCbParams)
 
 	// Fill beginning of args, up to the minimum of the total number of callback
 	// parameters and parameters from the glib runtime.
-	for i := 0; i < nCbParams && i < nGLibParams; i++ {
-		v := &Value{gValues[i]}
-		val, err := v.GoValue()
+	for i := 0; i < nCbParams && i < len(gValues); i++ {
+		rv, err := closureArg(&gValues[i], cc.rf.Type().In(i))
 		if err != nil {
-			fmt.Fprintf(os.Stderr,
-				"no suitable Go value for arg %d: %v\n", i, err)
-			return
+			return nil, fmt.Errorf("no suitable Go value for arg %d: %v", i, err)
 		}
-		rv := reflect.ValueOf(val)
-		args = append(args, rv.Convert(cc.rf.Type().In(i)))
+		args = append(args, rv)
 	}
 
 	// If non-nil user data was passed in and not all args have been set,
@@ -277,18 +493,62 @@ func goMarshal(closure *C.GClosure, retValue *C.GValue,
 	if cc.userData.IsValid() && len(args) < cap(args) {
 		args = append(args, cc.userData.Convert(cc.rf.Type().In(nCbParams-1)))
 	}
+	return args, nil
+}
 
-	// Call closure with args. If the callback returns one or more
-	// values, save the GValue equivalent of the first.
-	rv := cc.rf.Call(args)
-	if retValue != nil && len(rv) > 0 {
-		if g, err := GValue(rv[0].Interface()); err != nil {
-			fmt.Fprintf(os.Stderr,
-				"cannot save callback return value: %v", err)
-		} else {
-			*retValue = *g.native()
+// swappedArgs converts the user data of cc, followed by the parameters of
+// a closure invocation with the instance moved last, to the first
+// nCbParams arguments of cc.rf.
+func swappedArgs(cc closureContext, gValues []C.GValue, nCbParams int) ([]reflect.Value, error) {
+	args := make([]reflect.Value, 0, nCbParams)
+	if nCbParams > 0 && cc.userData.IsValid() {
+		args = append(args, cc.userData.Convert(cc.rf.Type().In(0)))
+	}
+
+	order := make([]int, 0, len(gValues))
+	for i := 1; i < len(gValues); i++ {
+		order = append(order, i)
+	}
+	if len(gValues) > 0 {
+		order = append(order, 0)
+	}
+	for _, i := range order {
+		if len(args) == nCbParams {
+			break
+		}
+		rv, err := closureArg(&gValues[i], cc.rf.Type().In(len(args)))
+		if err != nil {
+			return nil, fmt.Errorf("no suitable Go value for arg %d: %v", i, err)
+		}
+		args = append(args, rv)
+	}
+	return args, nil
+}
+
+// closureArg converts a closure parameter to a value of argType.
+func closureArg(gv *C.GValue, argType reflect.Type) (reflect.Value, error) {
+	v := &Value{*gv}
+	val, err := v.GoValue()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rv := reflect.ValueOf(val)
+	if !rv.IsValid() {
+		return reflect.Zero(argType), nil
+	}
+
+	// GVariant arguments are unmarshaled into the parameter type of
+	// the callback, unless it accepts a *Variant.
+	if variant, ok := val.(*Variant); ok && !rv.Type().AssignableTo(argType) {
+		p := reflect.New(argType)
+		if variant != nil {
+			if err := variant.Unmarshal(p.Interface()); err != nil {
+				return reflect.Value{}, err
+			}
 		}
+		rv = p.Elem()
 	}
+	return rv.Convert(argType), nil
 }
 
 // gValueSlice converts a C array of GValues to a Go slice.
@@ -306,6 +566,18 @@ func gValueSlice(values *C.GValue, nValues int) (slice []C.GValue) {
 
 type SourceHandle uint
 
+// Priority is a representation of the priorities of GLib event sources.
+// Sources with a lower value are dispatched first.
+type Priority int
+
+const (
+	PRIORITY_HIGH         Priority = C.G_PRIORITY_HIGH
+	PRIORITY_DEFAULT      Priority = C.G_PRIORITY_DEFAULT
+	PRIORITY_HIGH_IDLE    Priority = C.G_PRIORITY_HIGH_IDLE
+	PRIORITY_DEFAULT_IDLE Priority = C.G_PRIORITY_DEFAULT_IDLE
+	PRIORITY_LOW          Priority = C.G_PRIORITY_LOW
+)
+
 // IdleAdd adds an idle source to the default main event loop
 // context.  After running once, the source func will be removed
 // from the main event loop, unless f returns a single bool true.
@@ -313,6 +585,11 @@ type SourceHandle uint
 // This function will cause a panic when f eventually runs if the
 // types of args do not match those of f.
 func IdleAdd(f interface{}, args ...interface{}) (SourceHandle, error) {
+	return IdleAddFull(PRIORITY_DEFAULT_IDLE, f, args...)
+}
+
+// IdleAddFull behaves like IdleAdd, but runs f with the given priority.
+func IdleAddFull(priority Priority, f interface{}, args ...interface{}) (SourceHandle, error) {
 	// f must be a func with no parameters.
 	rf := reflect.ValueOf(f)
 	if rf.Type().Kind() != reflect.Func {
@@ -324,7 +601,8 @@ func IdleAdd(f interface{}, args ...interface{}) (SourceHandle, error) {
 	if idleSrc == nil {
 		return 0, errNilPtr
 	}
-	return sourceAttach(idleSrc, rf, args...)
+	C.g_source_set_priority(idleSrc, C.gint(priority))
+	return sourceAttach(idleSrc, nil, rf, args...)
 }
 
 // TimeoutAdd adds an timeout source to the default main event loop
@@ -335,6 +613,12 @@ func IdleAdd(f interface{}, args ...interface{}) (SourceHandle, error) {
 // types of args do not match those of f.
 // timeout is in milliseconds
 func TimeoutAdd(timeout uint, f interface{}, args ...interface{}) (SourceHandle, error) {
+	return TimeoutAddFull(PRIORITY_DEFAULT, timeout, f, args...)
+}
+
+// TimeoutAddFull behaves like TimeoutAdd, but runs f with the given
+// priority.
+func TimeoutAddFull(priority Priority, timeout uint, f interface{}, args ...interface{}) (SourceHandle, error) {
 	// f must be a func with no parameters.
 	rf := reflect.ValueOf(f)
 	if rf.Type().Kind() != reflect.Func {
@@ -346,12 +630,42 @@ func TimeoutAdd(timeout uint, f interface{}, args ...interface{}) (SourceHandle,
 	if timeoutSrc == nil {
 		return 0, errNilPtr
 	}
+	C.g_source_set_priority(timeoutSrc, C.gint(priority))
+	return sourceAttach(timeoutSrc, nil, rf, args...)
+}
+
+// TimeoutAddSeconds behaves like TimeoutAdd, but interval is in seconds
+// and uses g_timeout_source_new_seconds(), which lets GLib group timers
+// firing at the same second to save power.
+func TimeoutAddSeconds(interval uint, f interface{}, args ...interface{}) (SourceHandle, error) {
+	rf := reflect.ValueOf(f)
+	if rf.Type().Kind() != reflect.Func {
+		return 0, errors.New("f is not a function")
+	}
+
+	timeoutSrc := C.g_timeout_source_new_seconds(C.guint(interval))
+	if timeoutSrc == nil {
+		return 0, errNilPtr
+	}
+	return sourceAttach(timeoutSrc, nil, rf, args...)
+}
 
-	return sourceAttach(timeoutSrc, rf, args...)
+// SourceRemove is a wrapper around g_source_remove().  It removes a
+// source added to the default main event loop context, and returns false
+// if no such source exists, for example because it has already run for
+// the last time.
+func SourceRemove(handle SourceHandle) bool {
+	src := C.g_main_context_find_source_by_id(nil, C.guint(handle))
+	if src == nil {
+		return false
+	}
+	C.g_source_destroy(src)
+	return true
 }
 
-// sourceAttach attaches a source to the default main loop context.
-func sourceAttach(src *C.GSource, rf reflect.Value, args ...interface{}) (SourceHandle, error) {
+// sourceAttach attaches a source to a main loop context, or the default
+// context if ctx is nil.
+func sourceAttach(src *C.GSource, ctx *C.GMainContext, rf reflect.Value, args ...interface{}) (SourceHandle, error) {
 	if src == nil {
 		return 0, errNilPtr
 	}
@@ -359,43 +673,65 @@ func sourceAttach(src *C.GSource, rf reflect.Value, args ...interface{}) (Source
 	// rf must be a func with no parameters.
 	if rf.Type().Kind() != reflect.Func {
 		C.g_source_destroy(src)
+		C.g_source_unref(src)
 		return 0, errors.New("rf is not a function")
 	}
-
-	// Create a new GClosure from f that invalidates itself when
-	// f returns false.  The error is ignored here, as this will
-	// always be a function.
-	var closure *C.GClosure
-	closure, _ = ClosureNew(func() {
-		// Create a slice of reflect.Values arguments to call the func.
-		rargs := make([]reflect.Value, len(args))
-		for i := range args {
-			rargs[i] = reflect.ValueOf(args[i])
+	if debugChecks() {
+		if err := checkSourceFunc(rf, args); err != nil {
+			C.g_source_destroy(src)
+			C.g_source_unref(src)
+			return 0, err
 		}
+	}
 
+	// Create a closure which GLib removes along with the source when it
+	// returns false.
+	return sourceAttachClosure(src, ctx, func() bool {
 		// Call func with args. The callback will be removed, unless
 		// it returns exactly one return value of true.
-		rv := rf.Call(rargs)
-		if len(rv) == 1 {
-			if rv[0].Kind() == reflect.Bool {
-				if rv[0].Bool() {
-					return
-				}
-			}
+		return sourceKeep(callSourceFunc(rf, args))
+	})
+}
+
+// sourceKeep returns whether the values returned by a source func ask for
+// the source to be kept, which is the case if it returned exactly one
+// value of true.
+func sourceKeep(rv []reflect.Value) bool {
+	if len(rv) == 1 {
+		if rv[0].Kind() == reflect.Bool {
+			return rv[0].Bool()
 		}
-		C.g_closure_invalidate(closure)
+	}
+	return false
+}
+
+// sourceAttachClosure sets a new GClosure calling f as the callback of
+// src, and attaches src to a main loop context, or the default context if
+// ctx is nil.  f receives the parameters GLib passes to closures of the
+// particular source type, and must return a bool.
+func sourceAttachClosure(src *C.GSource, ctx *C.GMainContext, f interface{}) (SourceHandle, error) {
+	closure, err := ClosureNew(f)
+	if err != nil {
 		C.g_source_destroy(src)
-	})
+		C.g_source_unref(src)
+		return 0, err
+	}
+	closures.Lock()
+	cc := closures.m[closure]
+	cc.source = true
+	closures.m[closure] = cc
+	closures.Unlock()
 
 	// Remove closure context when closure is finalized.
 	C._g_closure_add_finalize_notifier(closure)
 
-	// Set closure to run as a callback when the idle source runs.
+	// Set closure to run as a callback when the source runs.
 	C.g_source_set_closure(src, closure)
 
-	// Attach the idle source func to the default main event loop
-	// context.
-	cid := C.g_source_attach(src, nil)
+	// Attach the source func to the main event loop context.  The context
+	// holds its own reference to the source from here on.
+	cid := C.g_source_attach(src, ctx)
+	C.g_source_unref(src)
 	return SourceHandle(cid), nil
 }
 
@@ -513,6 +849,16 @@ func (v *Object) StopEmission(s string) {
 		(*C.gchar)(cstr))
 }
 
+// StopEmissionByName is a wrapper around g_signal_stop_emission_by_name()
+// and stops the emission of signal with the given detail.  If detail is
+// empty, it is the same as StopEmission(signal).
+func (v *Object) StopEmissionByName(signal, detail string) {
+	if detail != "" {
+		signal += "::" + detail
+	}
+	v.StopEmission(signal)
+}
+
 // Set is a wrapper around g_object_set().  However, unlike
 // g_object_set(), this function only sets one name value pair.  Make
 // multiple calls to this function to set multiple properties.
@@ -534,6 +880,165 @@ func (v *Object) Set(name string, value interface{}) error {
 	return nil
 }
 
+// GetPropertyType returns the Type of the property name of v.  A non-nil
+// error is returned if v has no such property.
+func (v *Object) GetPropertyType(name string) (Type, error) {
+	pspec := v.FindProperty(name)
+	if pspec == nil {
+		return TYPE_INVALID, errors.New("unable to find property " + name)
+	}
+	return pspec.ValueType(), nil
+}
+
+// GetProperty is a wrapper around g_object_get_property().  The value of
+// the property is converted to a Go type using the same marshalers as
+// GoValue, and must be type asserted by the caller.
+func (v *Object) GetProperty(name string) (interface{}, error) {
+	pspec := v.FindProperty(name)
+	if pspec == nil {
+		return nil, errors.New("unable to find property " + name)
+	}
+	if !pspec.IsReadable() {
+		return nil, errors.New("property " + name + " is not readable")
+	}
+
+	val, err := ValueInit(pspec.ValueType())
+	if err != nil {
+		return nil, err
+	}
+
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	C.g_object_get_property(v.native(), (*C.gchar)(cstr), val.native())
+	return val.GoValue()
+}
+
+// GetPropertyBool is a convenience wrapper around GetProperty for
+// properties of type TYPE_BOOLEAN.
+func (v *Object) GetPropertyBool(name string) (bool, error) {
+	p, err := v.GetProperty(name)
+	if err != nil {
+		return false, err
+	}
+	if b, ok := p.(bool); ok {
+		return b, nil
+	}
+	return false, errors.New("property " + name + " is not a boolean")
+}
+
+// GetPropertyInt is a convenience wrapper around GetProperty for
+// properties of type TYPE_INT, TYPE_LONG or TYPE_ENUM.  Enums for which a
+// package registered a marshaler, such as gtk.Orientation, are converted
+// to int.
+func (v *Object) GetPropertyInt(name string) (int, error) {
+	p, err := v.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	if rv := reflect.ValueOf(p); rv.IsValid() && rv.CanInt() {
+		return int(rv.Int()), nil
+	}
+	return 0, errors.New("property " + name + " is not an int")
+}
+
+// GetPropertyUint is a convenience wrapper around GetProperty for
+// properties of type TYPE_UINT, TYPE_ULONG or TYPE_FLAGS.  As with
+// GetPropertyInt, flags for which a package registered a marshaler are
+// converted to uint.
+func (v *Object) GetPropertyUint(name string) (uint, error) {
+	p, err := v.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	if rv := reflect.ValueOf(p); rv.IsValid() && rv.CanUint() {
+		return uint(rv.Uint()), nil
+	}
+	return 0, errors.New("property " + name + " is not a uint")
+}
+
+// GetPropertyInt64 is a convenience wrapper around GetProperty for
+// properties of type TYPE_INT64.
+func (v *Object) GetPropertyInt64(name string) (int64, error) {
+	p, err := v.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	if i, ok := p.(int64); ok {
+		return i, nil
+	}
+	return 0, errors.New("property " + name + " is not an int64")
+}
+
+// GetPropertyUint64 is a convenience wrapper around GetProperty for
+// properties of type TYPE_UINT64.
+func (v *Object) GetPropertyUint64(name string) (uint64, error) {
+	p, err := v.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	if u, ok := p.(uint64); ok {
+		return u, nil
+	}
+	return 0, errors.New("property " + name + " is not a uint64")
+}
+
+// GetPropertyFloat is a convenience wrapper around GetProperty for
+// properties of type TYPE_FLOAT or TYPE_DOUBLE.
+func (v *Object) GetPropertyFloat(name string) (float64, error) {
+	p, err := v.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	switch f := p.(type) {
+	case float32:
+		return float64(f), nil
+	case float64:
+		return f, nil
+	}
+	return 0, errors.New("property " + name + " is not a float")
+}
+
+// GetPropertyString is a convenience wrapper around GetProperty for
+// properties of type TYPE_STRING.
+func (v *Object) GetPropertyString(name string) (string, error) {
+	p, err := v.GetProperty(name)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := p.(string); ok {
+		return s, nil
+	}
+	return "", errors.New("property " + name + " is not a string")
+}
+
+// GetPropertyObject is a convenience wrapper around GetProperty for
+// properties holding a GObject.  nil is returned if the property is
+// unset.  Objects wrapped by another package, such as *gtk.Adjustment,
+// are returned as their underlying *Object.
+func (v *Object) GetPropertyObject(name string) (*Object, error) {
+	p, err := v.GetProperty(name)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, nil
+	}
+	if obj, ok := p.(IObject); ok {
+		return obj.toObject(), nil
+	}
+	return nil, errors.New("property " + name + " is not an object")
+}
+
+// FreezeNotify is a wrapper around g_object_freeze_notify().
+func (v *Object) FreezeNotify() {
+	C.g_object_freeze_notify(v.native())
+}
+
+// ThawNotify is a wrapper around g_object_thaw_notify().
+func (v *Object) ThawNotify() {
+	C.g_object_thaw_notify(v.native())
+}
+
 // pointerVal attempts to return an unsafe.Pointer for value.
 // Not all types are understood, in which case a nil Pointer
 // is returned.
@@ -657,8 +1162,17 @@ func (v *Object) Emit(s string, args ...interface{}) (interface{}, error) {
 	t := v.TypeFromInstance()
 	// TODO: use just the signal name
 	id := C.g_signal_lookup((*C.gchar)(cstr), C.GType(t))
+	info, err := SignalQuery(uint(id))
+	if err != nil {
+		return nil, errors.New("Unable to find signal " + s)
+	}
 
-	ret, err := ValueAlloc()
+	if info.ReturnType == TYPE_NONE {
+		C.g_signal_emitv(valv, id, C.GQuark(0), nil)
+		return nil, nil
+	}
+
+	ret, err := ValueInit(info.ReturnType)
 	if err != nil {
 		return nil, errors.New("Error creating Value for return value")
 	}
@@ -679,10 +1193,150 @@ func (v *Object) HandlerUnblock(handle SignalHandle) {
 
 // HandlerDisconnect is a wrapper around g_signal_handler_disconnect().
 func (v *Object) HandlerDisconnect(handle SignalHandle) {
+	// The closure is finalized once GLib drops its last reference, which
+	// removes it from the closures and signals maps.
 	C.g_signal_handler_disconnect(C.gpointer(v.GObject), C.gulong(handle))
-	C.g_closure_invalidate(signals[handle])
-	delete(closures.m, signals[handle])
-	delete(signals, handle)
+}
+
+// HandlerIsConnected is a wrapper around g_signal_handler_is_connected().
+func (v *Object) HandlerIsConnected(handle SignalHandle) bool {
+	c := C.g_signal_handler_is_connected(C.gpointer(v.GObject), C.gulong(handle))
+	return gobool(c)
+}
+
+// HandlersBlockMatched is a wrapper around
+// g_signal_handlers_block_matched() and blocks every handler of v
+// connected with the func f.  It returns the number of handlers blocked.
+//
+// Funcs are matched by identity: f must be the func value which was
+// connected, rather than an equal func literal or method value evaluated
+// again, as each evaluation of a method value such as d.onChanged creates
+// a distinct func.  Use HandlersBlockByData to block the handlers of one
+// receiver without keeping its method values.
+func (v *Object) HandlersBlockMatched(f interface{}) int {
+	return v.handlersMatched(funcMatcher(f), handlersBlock)
+}
+
+// HandlersUnblockMatched is a wrapper around
+// g_signal_handlers_unblock_matched() and unblocks every handler of v
+// connected with the func f, matched as by HandlersBlockMatched.  It
+// returns the number of handlers unblocked.
+func (v *Object) HandlersUnblockMatched(f interface{}) int {
+	return v.handlersMatched(funcMatcher(f), handlersUnblock)
+}
+
+// HandlersDisconnectMatched is a wrapper around
+// g_signal_handlers_disconnect_matched() and disconnects every handler
+// of v connected with the func f, matched as by HandlersBlockMatched.  It
+// returns the number of handlers disconnected.
+func (v *Object) HandlersDisconnectMatched(f interface{}) int {
+	return v.handlersMatched(funcMatcher(f), handlersDisconnect)
+}
+
+// HandlersBlockByData blocks every handler of v connected with data as
+// its user data, which must be comparable.  Passing the receiver of a
+// method value as user data allows blocking the handlers of one receiver,
+// such as a dialog, without affecting those of other receivers of the
+// same method.  It returns the number of handlers blocked.
+func (v *Object) HandlersBlockByData(data interface{}) int {
+	return v.handlersMatched(dataMatcher(data), handlersBlock)
+}
+
+// HandlersUnblockByData unblocks every handler of v connected with data
+// as its user data.  It returns the number of handlers unblocked.
+func (v *Object) HandlersUnblockByData(data interface{}) int {
+	return v.handlersMatched(dataMatcher(data), handlersUnblock)
+}
+
+// HandlersDisconnectByData disconnects every handler of v connected with
+// data as its user data.  It returns the number of handlers disconnected.
+func (v *Object) HandlersDisconnectByData(data interface{}) int {
+	return v.handlersMatched(dataMatcher(data), handlersDisconnect)
+}
+
+// The closures of Go handlers are all marshaled by the same C function,
+// so handlers are matched in Go and then passed to GLib by closure.
+
+func handlersBlock(instance C.gpointer, closure *C.GClosure) C.guint {
+	return C.g_signal_handlers_block_matched(instance,
+		C.G_SIGNAL_MATCH_CLOSURE, 0, 0, closure, nil, nil)
+}
+
+func handlersUnblock(instance C.gpointer, closure *C.GClosure) C.guint {
+	return C.g_signal_handlers_unblock_matched(instance,
+		C.G_SIGNAL_MATCH_CLOSURE, 0, 0, closure, nil, nil)
+}
+
+func handlersDisconnect(instance C.gpointer, closure *C.GClosure) C.guint {
+	return C.g_signal_handlers_disconnect_matched(instance,
+		C.G_SIGNAL_MATCH_CLOSURE, 0, 0, closure, nil, nil)
+}
+
+// funcMatcher returns a function reporting whether a closure runs the
+// func f.  Code pointers alone are shared by all closures of a func
+// literal and all method values of a method, so the func values, which
+// point to the captured variables or receiver, are compared as well.
+func funcMatcher(f interface{}) func(closureContext) bool {
+	rf := reflect.ValueOf(f)
+	if !rf.IsValid() || rf.Kind() != reflect.Func || rf.IsNil() {
+		return nil
+	}
+	code, id := rf.Pointer(), funcIdentity(f)
+	return func(cc closureContext) bool {
+		return cc.rf.Kind() == reflect.Func && cc.rf.Pointer() == code &&
+			funcIdentity(cc.rf.Interface()) == id
+	}
+}
+
+// funcIdentity returns the address of the func value f.  Func values are
+// pointers, stored directly in the data word of an interface.
+func funcIdentity(f interface{}) uintptr {
+	return uintptr((*[2]unsafe.Pointer)(unsafe.Pointer(&f))[1])
+}
+
+// dataMatcher returns a function reporting whether a closure was
+// connected with data as its user data.
+func dataMatcher(data interface{}) func(closureContext) bool {
+	rd := reflect.ValueOf(data)
+	if !rd.IsValid() || !rd.Comparable() {
+		return nil
+	}
+	return func(cc closureContext) bool {
+		return cc.userData.IsValid() && cc.userData.Type() == rd.Type() &&
+			cc.userData.Comparable() && cc.userData.Equal(rd)
+	}
+}
+
+// handlersMatched calls op for each closure connected to v for which
+// match returns true and returns the sum of the results.
+func (v *Object) handlersMatched(match func(closureContext) bool, op func(C.gpointer, *C.GClosure) C.guint) int {
+	if match == nil {
+		return 0
+	}
+
+	var connected []*C.GClosure
+	signals.Lock()
+	for c, key := range signals.byClosure {
+		if key.instance == v.native() {
+			connected = append(connected, c)
+		}
+	}
+	signals.Unlock()
+
+	var matched []*C.GClosure
+	closures.RLock()
+	for _, c := range connected {
+		if cc, ok := closures.m[c]; ok && match(cc) {
+			matched = append(matched, c)
+		}
+	}
+	closures.RUnlock()
+
+	n := 0
+	for _, c := range matched {
+		n += int(op(C.gpointer(v.GObject), c))
+	}
+	return n
 }
 
 /*
@@ -707,44 +1361,6 @@ func (v *InitiallyUnowned) Native() uintptr {
 	return v.Object.Native()
 }
 
-/*
- * Linked Lists
- */
-
-// List is a representation of Glib's GList.
-type List struct {
-	Data uintptr
-	Next *List
-	Prev *List
-}
-
-// Append is a wrapper around g_list_append.
-func (v *List) Append(data uintptr) *List {
-	glist := (*C.GList)(unsafe.Pointer(v))
-	glist = C.g_list_append(glist, C.gpointer(data))
-	return (*List)(unsafe.Pointer(glist))
-}
-
-// Prepend is a wrapper around g_list_prepend.
-func (v *List) Prepend(data uintptr) *List {
-	glist := (*C.GList)(unsafe.Pointer(v))
-	glist = C.g_list_prepend(glist, C.gpointer(data))
-	return (*List)(unsafe.Pointer(glist))
-}
-
-// Insert is a wrapper around g_list_insert().
-func (v *List) Insert(data uintptr, position int) *List {
-	glist := (*C.GList)(unsafe.Pointer(v))
-	glist = C.g_list_insert(glist, C.gpointer(data), C.gint(position))
-	return (*List)(unsafe.Pointer(glist))
-}
-
-// SList is a representation of Glib's GSList.
-type SList struct {
-	Data uintptr
-	Next *SList
-}
-
 /*
  * GValue
  */
@@ -905,6 +1521,14 @@ func GValue(v interface{}) (gvalue *Value, err error) {
 		val.SetString(e)
 		return val, nil
 
+	case *Variant:
+		val, err := ValueInit(TYPE_VARIANT)
+		if err != nil {
+			return nil, err
+		}
+		val.SetVariant(e)
+		return val, nil
+
 	case *Object:
 		val, err := ValueInit(TYPE_OBJECT)
 		if err != nil {
@@ -1002,6 +1626,7 @@ var gValueMarshalers = marshalMap{
 	TYPE_STRING:    marshalString,
 	TYPE_POINTER:   marshalPointer,
 	TYPE_BOXED:     marshalBoxed,
+	TYPE_PARAM:     marshalParamSpec,
 	TYPE_OBJECT:    marshalObject,
 	TYPE_VARIANT:   marshalVariant,
 }
@@ -1121,11 +1746,15 @@ func marshalPointer(p uintptr) (interface{}, error) {
 
 func marshalObject(p uintptr) (interface{}, error) {
 	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	if c == nil {
+		return nil, nil
+	}
 	return newObject((*C.GObject)(c)), nil
 }
 
 func marshalVariant(p uintptr) (interface{}, error) {
-	return nil, errors.New("variant conversion not yet implemented")
+	c := C.g_value_dup_variant((*C.GValue)(unsafe.Pointer(p)))
+	return takeVariant(c), nil
 }
 
 // GoValue converts a Value to comparable Go type.  GoValue()
@@ -1212,6 +1841,11 @@ func (v *Value) SetPointer(p uintptr) {
 	C.g_value_set_pointer(v.native(), C.gpointer(p))
 }
 
+// SetVariant is a wrapper around g_value_set_variant().
+func (v *Value) SetVariant(val *Variant) {
+	C.g_value_set_variant(v.native(), val.native())
+}
+
 // GetString is a wrapper around g_value_get_string().  GetString()
 // returns a non-nil error if g_value_get_string() returned a NULL
 // pointer to distinguish between returning a NULL pointer and returning
@@ -27,12 +27,55 @@ toGObject(void *p)
 	return (G_OBJECT(p));
 }
 
+/* GVariant Type Casting */
+static GVariant *
+toGVariant(void *p)
+{
+	return ((GVariant *)p);
+}
+
+static GVariantType *
+toGVariantType(void *p)
+{
+	return ((GVariantType *)p);
+}
+
+static GVariantIter *
+toGVariantIter(void *p)
+{
+	return ((GVariantIter *)p);
+}
+
+static GVariantBuilder *
+toGVariantBuilder(void *p)
+{
+	return ((GVariantBuilder *)p);
+}
+
+static GVariantDict *
+toGVariantDict(void *p)
+{
+	return ((GVariantDict *)p);
+}
+
 static GType
 _g_type_from_instance(gpointer instance)
 {
 	return (G_TYPE_FROM_INSTANCE(instance));
 }
 
+static GObjectClass *
+_g_object_get_class(GObject *object)
+{
+	return (G_OBJECT_GET_CLASS(object));
+}
+
+static GType
+_g_type_from_class(gpointer g_class)
+{
+	return (G_TYPE_FROM_CLASS(g_class));
+}
+
 /* Wrapper to avoid variable arg list */
 static void
 _g_object_set_one(gpointer object, const gchar *property_name, void *val)
@@ -55,6 +98,256 @@ val_list_insert(GValue *valv, int i, GValue *val)
 	valv[i] = *val;
 }
 
+/*
+ * String and GVariant arrays
+ */
+
+static gchar **
+alloc_strv(int n)
+{
+	return (g_new0(gchar *, n + 1));
+}
+
+static void
+strv_set(gchar **strv, int i, gchar *str)
+{
+	strv[i] = str;
+}
+
+static const gchar *
+strv_get(const gchar **strv, int i)
+{
+	return (strv[i]);
+}
+
+static GVariant **
+alloc_variant_list(int n)
+{
+	return (g_new0(GVariant *, n));
+}
+
+static void
+variant_list_insert(GVariant **list, int i, GVariant *v)
+{
+	list[i] = v;
+}
+
+static const GVariantType **
+alloc_variant_type_list(int n)
+{
+	return (g_new0(const GVariantType *, n));
+}
+
+static void
+variant_type_list_insert(const GVariantType **list, int i, const GVariantType *t)
+{
+	list[i] = t;
+}
+
+static GVariant *
+_g_variant_new_byte_array(gconstpointer data, gsize n)
+{
+	return (g_variant_new_fixed_array(G_VARIANT_TYPE_BYTE, data, n, 1));
+}
+
+/*
+ * GParamSpec
+ */
+
+static GParamSpec *
+pspec_list_get(GParamSpec **list, guint i)
+{
+	return (list[i]);
+}
+
+/*
+ * Initializes min and max with the value type of pspec and sets them to
+ * the bounds of a numeric pspec.  Returns FALSE, leaving the values
+ * untouched, if pspec has no range.
+ */
+static gboolean
+_g_param_spec_range(GParamSpec *pspec, GValue *min, GValue *max)
+{
+#define PSPEC_RANGE(check, cast, setter)			\
+	if (check(pspec)) {					\
+		g_value_init(min, pspec->value_type);		\
+		g_value_init(max, pspec->value_type);		\
+		setter(min, cast(pspec)->minimum);		\
+		setter(max, cast(pspec)->maximum);		\
+		return (TRUE);					\
+	}
+
+	PSPEC_RANGE(G_IS_PARAM_SPEC_CHAR, G_PARAM_SPEC_CHAR, g_value_set_schar)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_UCHAR, G_PARAM_SPEC_UCHAR, g_value_set_uchar)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_INT, G_PARAM_SPEC_INT, g_value_set_int)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_UINT, G_PARAM_SPEC_UINT, g_value_set_uint)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_LONG, G_PARAM_SPEC_LONG, g_value_set_long)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_ULONG, G_PARAM_SPEC_ULONG, g_value_set_ulong)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_INT64, G_PARAM_SPEC_INT64, g_value_set_int64)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_UINT64, G_PARAM_SPEC_UINT64, g_value_set_uint64)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_FLOAT, G_PARAM_SPEC_FLOAT, g_value_set_float)
+	PSPEC_RANGE(G_IS_PARAM_SPEC_DOUBLE, G_PARAM_SPEC_DOUBLE, g_value_set_double)
+#undef PSPEC_RANGE
+
+	return (FALSE);
+}
+
+/*
+ * GSignal
+ */
+
+enum {
+	GOTK3_SIGNAL_ACCUMULATOR_NONE,
+	GOTK3_SIGNAL_ACCUMULATOR_TRUE_HANDLED,
+	GOTK3_SIGNAL_ACCUMULATOR_FIRST_WINS
+};
+
+static guint
+_g_signal_newv(const gchar *name, GType itype, GSignalFlags flags,
+    int accumulator, GType return_type, guint n_params, GType *param_types)
+{
+	GSignalAccumulator	 acc;
+
+	switch (accumulator) {
+	case GOTK3_SIGNAL_ACCUMULATOR_TRUE_HANDLED:
+		acc = g_signal_accumulator_true_handled;
+		break;
+	case GOTK3_SIGNAL_ACCUMULATOR_FIRST_WINS:
+		acc = g_signal_accumulator_first_wins;
+		break;
+	default:
+		acc = NULL;
+	}
+	return (g_signal_newv(name, itype, flags, NULL, acc, NULL, NULL,
+	    return_type, n_params, param_types));
+}
+
+static GType
+gtype_list_get(GType *list, guint i)
+{
+	return (list[i]);
+}
+
+static guint
+guint_list_get(guint *list, guint i)
+{
+	return (list[i]);
+}
+
+static gint
+gint_list_get(gint *list, gsize i)
+{
+	return (list[i]);
+}
+
+static gboolean
+gboolean_list_get(gboolean *list, gsize i)
+{
+	return (list[i]);
+}
+
+static gdouble
+gdouble_list_get(gdouble *list, gsize i)
+{
+	return (list[i]);
+}
+
+/*
+ * Go-defined GObject types
+ */
+
+extern void	goClassInit(gpointer, guint);
+extern void	goInstanceInit(GTypeInstance *, gpointer);
+extern void	goObjectSetProperty(GObject *, guint, GValue *, GParamSpec *);
+extern void	goObjectGetProperty(GObject *, guint, GValue *, GParamSpec *);
+extern void	goObjectFinalize(GObject *);
+
+static GQuark
+_go_type_quark()
+{
+	return (g_quark_from_static_string("gotk3-go-type"));
+}
+
+static void
+_go_object_set_property(GObject *object, guint property_id,
+    const GValue *value, GParamSpec *pspec)
+{
+	goObjectSetProperty(object, property_id, (GValue *)value, pspec);
+}
+
+static void
+_go_object_get_property(GObject *object, guint property_id, GValue *value,
+    GParamSpec *pspec)
+{
+	goObjectGetProperty(object, property_id, value, pspec);
+}
+
+/*
+ * Every Go-defined type uses this finalize, so chain up to the first
+ * ancestor which is not defined in Go.
+ */
+static void
+_go_object_finalize(GObject *object)
+{
+	GType		 t;
+	GObjectClass	*parent_class;
+
+	goObjectFinalize(object);
+
+	t = G_OBJECT_TYPE(object);
+	while (g_type_get_qdata(t, _go_type_quark()) != NULL)
+		t = g_type_parent(t);
+	parent_class = G_OBJECT_CLASS(g_type_class_peek(t));
+	parent_class->finalize(object);
+}
+
+static void
+_go_class_init(gpointer g_class, gpointer class_data)
+{
+	GObjectClass	*object_class;
+
+	object_class = G_OBJECT_CLASS(g_class);
+	object_class->set_property = _go_object_set_property;
+	object_class->get_property = _go_object_get_property;
+	object_class->finalize = _go_object_finalize;
+	goClassInit(g_class, GPOINTER_TO_UINT(class_data));
+}
+
+static void
+_go_instance_init(GTypeInstance *instance, gpointer g_class)
+{
+	goInstanceInit(instance, g_class);
+}
+
+static GType
+_go_type_register(GType parent, const gchar *name, guint id)
+{
+	GTypeQuery	 query;
+	GTypeInfo	 info = { 0 };
+	GType		 t;
+
+	g_type_query(parent, &query);
+	if (query.type == G_TYPE_INVALID)
+		return (G_TYPE_INVALID);
+
+	info.class_size = query.class_size;
+	info.class_init = _go_class_init;
+	info.class_data = GUINT_TO_POINTER(id);
+	info.instance_size = query.instance_size;
+	info.instance_init = _go_instance_init;
+
+	t = g_type_register_static(parent, name, &info, 0);
+	if (t != G_TYPE_INVALID)
+		g_type_set_qdata(t, _go_type_quark(), GUINT_TO_POINTER(id));
+	return (t);
+}
+
+static GObject *
+_g_object_new(GType t)
+{
+	return (g_object_new(t, NULL));
+}
+
 /*
  * GValue
  */
@@ -115,3 +408,131 @@ _g_closure_add_finalize_notifier(GClosure *closure)
 {
 	g_closure_add_finalize_notifier(closure, NULL, removeClosure);
 }
+
+/*
+ * Message logging
+ */
+
+extern GLogWriterOutput	goLogWriter(GLogLevelFlags, GLogField *, gsize, gboolean *);
+
+static GLogWriterOutput
+_g_log_writer(GLogLevelFlags log_level, const GLogField *fields,
+    gsize n_fields, gpointer user_data)
+{
+	GLogWriterOutput	 ret;
+	gboolean		 fatal = FALSE;
+
+	ret = goLogWriter(log_level, (GLogField *)fields, n_fields, &fatal);
+	if (fatal)
+		abort();
+	return (ret);
+}
+
+static void
+_g_log_set_writer_func()
+{
+	g_log_set_writer_func(_g_log_writer, NULL, NULL);
+}
+
+static GLogField *
+_g_log_field_get(GLogField *fields, gsize i)
+{
+	return (&fields[i]);
+}
+
+static GLogField *
+_g_log_fields_new(gsize n)
+{
+	return (g_new0(GLogField, n));
+}
+
+static void
+_g_log_field_set(GLogField *fields, gsize i, char *key, char *value)
+{
+	fields[i].key = key;
+	fields[i].value = value;
+	fields[i].length = -1;
+}
+
+static void
+_g_log_fields_free(GLogField *fields, gsize n)
+{
+	gsize	i;
+
+	for (i = 0; i < n; i++) {
+		free((char *)fields[i].key);
+		free((char *)fields[i].value);
+	}
+	g_free(fields);
+}
+
+/*
+ * GBytes
+ */
+
+extern void	goBytesFree(gpointer);
+
+static void
+_g_bytes_free(gpointer user_data)
+{
+	goBytesFree(user_data);
+}
+
+static GBytes *
+_g_bytes_new_pinned(gconstpointer data, gsize size, guintptr id)
+{
+	return (g_bytes_new_with_free_func(data, size, _g_bytes_free,
+	    (gpointer)id));
+}
+
+/*
+ * GHashTable
+ */
+
+static GHashTable *
+_g_hash_table_new(gboolean str_keys, gboolean free_keys, gboolean free_values)
+{
+	GHashFunc	 hash = NULL;
+	GEqualFunc	 equal = NULL;
+
+	if (str_keys) {
+		hash = g_str_hash;
+		equal = g_str_equal;
+	}
+	return (g_hash_table_new_full(hash, equal,
+	    free_keys ? g_free : NULL, free_values ? g_free : NULL));
+}
+
+/*
+ * GPtrArray
+ */
+
+static gpointer
+_g_ptr_array_index(GPtrArray *array, guint i)
+{
+	return (g_ptr_array_index(array, i));
+}
+
+/*
+ * GTimeZone
+ */
+
+static const gchar *
+_g_time_zone_get_identifier(GTimeZone *tz)
+{
+#if GLIB_CHECK_VERSION(2, 58, 0)
+	return (g_time_zone_get_identifier(tz));
+#else
+	return (NULL);
+#endif
+}
+
+static const gchar *
+_g_date_time_get_timezone_identifier(GDateTime *datetime)
+{
+#if GLIB_CHECK_VERSION(2, 58, 0)
+	return (g_time_zone_get_identifier(g_date_time_get_timezone(datetime)));
+#else
+	return (NULL);
+#endif
+}
@@ -0,0 +1,170 @@
+//glib_check : validation of Go callbacks against the values GLib passes them
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"unsafe"
+)
+
+var debugEnabled int32
+
+// SetDebug enables or disables additional checks which are too costly to
+// always perform.  Currently these validate the funcs and arguments
+// passed to IdleAdd, TimeoutAdd and their variants when the source is
+// added, instead of panicking when it runs.
+func SetDebug(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&debugEnabled, v)
+}
+
+func debugChecks() bool {
+	return atomic.LoadInt32(&debugEnabled) != 0
+}
+
+// signalQueryDetailed returns the information about detailedSignal for
+// instances of itype.
+func signalQueryDetailed(detailedSignal string, itype Type) (*SignalInfo, error) {
+	cstr := C.CString(detailedSignal)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var id C.guint
+	var detail C.GQuark
+	if !gobool(C.g_signal_parse_name((*C.gchar)(cstr), C.GType(itype),
+		&id, &detail, gbool(false))) {
+		return nil, fmt.Errorf("no signal %q for type %s", detailedSignal, itype.Name())
+	}
+	return SignalQuery(uint(id))
+}
+
+// checkSignalFunc returns a non-nil error describing why rf, with the
+// optional user data userData, cannot be called for the signal info.  The
+// rules match the conversions done by goMarshal.
+func checkSignalFunc(info *SignalInfo, rf reflect.Value, userData reflect.Value, swapped bool) error {
+	ft := rf.Type()
+	if ft.IsVariadic() {
+		return fmt.Errorf("callback for signal %q must not be variadic", info.Name)
+	}
+
+	// slots holds the GType of each value the callback may receive, or
+	// TYPE_INVALID for the user data.
+	slots := []Type{info.OwnerType}
+	slots = append(slots, info.ParamTypes...)
+	switch {
+	case swapped:
+		slots = append(slots[1:], info.OwnerType)
+		if userData.IsValid() {
+			slots = append([]Type{TYPE_INVALID}, slots...)
+		}
+	case userData.IsValid():
+		slots = append(slots, TYPE_INVALID)
+	}
+
+	if ft.NumIn() > len(slots) {
+		return fmt.Errorf("callback for signal %q takes %d args, but at most %d are passed",
+			info.Name, ft.NumIn(), len(slots))
+	}
+	for i := 0; i < ft.NumIn(); i++ {
+		if slots[i] == TYPE_INVALID {
+			if !userData.Type().ConvertibleTo(ft.In(i)) {
+				return fmt.Errorf("callback for signal %q: arg %d has type %s, but user data is %s",
+					info.Name, i, ft.In(i), userData.Type())
+			}
+			continue
+		}
+		if !goTypeHolds(ft.In(i), slots[i]) {
+			return fmt.Errorf("callback for signal %q: arg %d has type %s, but signal passes %s",
+				info.Name, i, ft.In(i), slots[i].Name())
+		}
+	}
+
+	// Return values are optional, as the return value of the emission is
+	// then left unchanged.
+	if ft.NumOut() > 0 {
+		if info.ReturnType == TYPE_NONE {
+			return fmt.Errorf("callback for signal %q returns a value, but signal returns none",
+				info.Name)
+		}
+		if !goTypeHolds(ft.Out(0), info.ReturnType) {
+			return fmt.Errorf("callback for signal %q returns %s, but signal returns %s",
+				info.Name, ft.Out(0), info.ReturnType.Name())
+		}
+	}
+	return nil
+}
+
+// goTypeHolds returns whether values of the GType t may be converted to
+// and from the Go type goType.  Boxed and pointer types are marshaled by
+// other packages to types unknown here, so only obviously wrong Go types
+// are rejected for them.
+func goTypeHolds(goType reflect.Type, t Type) bool {
+	if goType.Kind() == reflect.Interface {
+		return true
+	}
+
+	switch Type(C._g_value_fundamental(C.GType(t))) {
+	case TYPE_BOOLEAN:
+		return goType.Kind() == reflect.Bool
+	case TYPE_CHAR, TYPE_UCHAR, TYPE_INT, TYPE_UINT, TYPE_LONG, TYPE_ULONG,
+		TYPE_INT64, TYPE_UINT64, TYPE_ENUM, TYPE_FLAGS, TYPE_FLOAT, TYPE_DOUBLE:
+		return isNumericKind(goType.Kind())
+	case TYPE_STRING:
+		return goType.Kind() == reflect.String
+	case TYPE_OBJECT, TYPE_INTERFACE, TYPE_PARAM:
+		return goType.Kind() == reflect.Ptr
+	case TYPE_BOXED, TYPE_POINTER:
+		return goType.Kind() != reflect.Bool && goType.Kind() != reflect.String
+	default:
+		return true
+	}
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// checkSourceFunc returns a non-nil error if rf cannot be called with
+// args by callSourceFunc.
+func checkSourceFunc(rf reflect.Value, args []interface{}) error {
+	ft := rf.Type()
+	if ft.IsVariadic() {
+		if len(args) < ft.NumIn()-1 {
+			return fmt.Errorf("source func takes at least %d args, but %d are passed",
+				ft.NumIn()-1, len(args))
+		}
+	} else if len(args) != ft.NumIn() {
+		return fmt.Errorf("source func takes %d args, but %d are passed",
+			ft.NumIn(), len(args))
+	}
+
+	for i, arg := range args {
+		var in reflect.Type
+		if ft.IsVariadic() && i >= ft.NumIn()-1 {
+			in = ft.In(ft.NumIn() - 1).Elem()
+		} else {
+			in = ft.In(i)
+		}
+		if arg == nil {
+			return fmt.Errorf("source func arg %d is nil", i)
+		}
+		if t := reflect.TypeOf(arg); !t.AssignableTo(in) {
+			return fmt.Errorf("source func arg %d has type %s, but %s is passed", i, in, t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+//glib_context : integration of event sources with context.Context
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// IdleAddContext behaves like IdleAdd, but the source is removed once ctx
+// is done.  f is never called after ctx is done.  If ctx is already done,
+// no source is added and ctx.Err() is returned.
+func IdleAddContext(ctx context.Context, f interface{}, args ...interface{}) (SourceHandle, error) {
+	return addContextSource(ctx, f, args, func() *C.GSource {
+		return C.g_idle_source_new()
+	})
+}
+
+// TimeoutAddContext behaves like TimeoutAdd, but the source is removed
+// once ctx is done.  f is never called after ctx is done.  If ctx is
+// already done, no source is added and ctx.Err() is returned.
+func TimeoutAddContext(ctx context.Context, timeout uint, f interface{}, args ...interface{}) (SourceHandle, error) {
+	return addContextSource(ctx, f, args, func() *C.GSource {
+		return C.g_timeout_source_new(C.guint(timeout))
+	})
+}
+
+// addContextSource attaches the source created by newSource to the
+// default main context, calling f with args, and destroys the source when
+// ctx is done.
+func addContextSource(ctx context.Context, f interface{}, args []interface{},
+	newSource func() *C.GSource) (SourceHandle, error) {
+
+	rf := reflect.ValueOf(f)
+	if rf.Type().Kind() != reflect.Func {
+		return 0, errors.New("f is not a function")
+	}
+	if debugChecks() {
+		if err := checkSourceFunc(rf, args); err != nil {
+			return 0, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	src := newSource()
+	if src == nil {
+		return 0, errNilPtr
+	}
+
+	// Keep a reference to the source, rather than looking it up by its
+	// ID, which GLib may reuse once the source has removed itself.
+	C.g_source_ref(src)
+
+	// finished is closed once the source has removed itself, so the
+	// goroutine waiting on ctx does not outlive it.
+	finished := make(chan struct{})
+	var once sync.Once
+	finish := func() {
+		once.Do(func() { close(finished) })
+	}
+
+	handle, err := sourceAttachClosure(src, nil, func() bool {
+		if ctx.Err() == nil && sourceKeep(callSourceFunc(rf, args)) {
+			return true
+		}
+		finish()
+		return false
+	})
+	if err != nil {
+		C.g_source_unref(src)
+		return 0, err
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-finished:
+		}
+		// g_source_destroy() is thread-safe and does nothing for
+		// sources which have already been destroyed.
+		C.g_source_destroy(src)
+		C.g_source_unref(src)
+	}()
+	return handle, nil
+}
@@ -0,0 +1,109 @@
+//glib_invoke : running Go functions on the thread of the main event loop
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include "glib.go.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// InvokeResult holds the outcome of a function run by InvokeAsync.
+type InvokeResult struct {
+	// Values holds the values returned by the function.
+	Values []interface{}
+
+	// Err is non-nil if the function could not be run, or is an
+	// *InvokePanicError if it panicked.
+	Err error
+}
+
+// InvokePanicError is the error reported by InvokeSync and InvokeAsync
+// when the invoked function panics.
+type InvokePanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+
+	// Stack is the stack trace of the goroutine which panicked.
+	Stack []byte
+}
+
+func (e *InvokePanicError) Error() string {
+	return fmt.Sprintf("invoked function panicked: %v", e.Value)
+}
+
+// IsMainThread returns whether the calling goroutine runs on the thread
+// which currently owns the default main event loop context, such as inside
+// a signal handler or source func dispatched by gtk.Main.  It returns
+// false before gtk.Main runs, even on the thread which will run it.
+func IsMainThread() bool {
+	return gobool(C.g_main_context_is_owner(C.g_main_context_default()))
+}
+
+// InvokeAsync runs f with args on the thread of the default main event
+// loop and returns a channel which receives the result once f has
+// returned.  A panic in f is recovered and reported as an
+// *InvokePanicError.
+//
+// As with g_main_context_invoke(), f is run before InvokeAsync returns if
+// the calling thread owns the default context, or can acquire it because
+// no main loop is running and no other thread-default context was pushed,
+// such as while setting up the application before gtk.Main.  Otherwise f
+// is added as a PRIORITY_DEFAULT source, and only runs while the main loop
+// is running.
+func InvokeAsync(f interface{}, args ...interface{}) <-chan InvokeResult {
+	ch := make(chan InvokeResult, 1)
+
+	rf := reflect.ValueOf(f)
+	if rf.Type().Kind() != reflect.Func {
+		ch <- InvokeResult{Err: errors.New("f is not a function")}
+		return ch
+	}
+
+	call := func() bool {
+		ch <- invokeCall(rf, args)
+		return false
+	}
+	if MainContextDefault().invokeOwned(func() { call() }) {
+		return ch
+	}
+	if _, err := IdleAddFull(PRIORITY_DEFAULT, call); err != nil {
+		ch <- InvokeResult{Err: err}
+	}
+	return ch
+}
+
+// InvokeSync runs f with args on the thread of the default main event loop
+// as InvokeAsync does, and blocks until f has returned.  The values
+// returned by f are returned in order.  A panic in f is recovered and
+// returned as an *InvokePanicError.
+//
+// InvokeSync runs f immediately when called from the thread running the
+// main loop or while no main loop is running.  Called from a thread which
+// pushed another thread-default context, it waits for the main loop to
+// run f, and blocks forever if the main loop is never run.
+func InvokeSync(f interface{}, args ...interface{}) ([]interface{}, error) {
+	res := <-InvokeAsync(f, args...)
+	return res.Values, res.Err
+}
+
+// invokeCall calls rf with args, recovering any panic.
+func invokeCall(rf reflect.Value, args []interface{}) (res InvokeResult) {
+	defer func() {
+		if p := recover(); p != nil {
+			res = InvokeResult{Err: &InvokePanicError{Value: p, Stack: debug.Stack()}}
+		}
+	}()
+
+	rv := callSourceFunc(rf, args)
+	res.Values = make([]interface{}, len(rv))
+	for i := range rv {
+		res.Values[i] = rv[i].Interface()
+	}
+	return res
+}
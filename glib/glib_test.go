@@ -1,10 +1,17 @@
 package glib_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"github.com/conformal/gotk3/glib"
 	"github.com/conformal/gotk3/gtk"
+	"log/slog"
+	"reflect"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -55,3 +62,1137 @@ func TestTimeoutAdd(t *testing.T) {
 
 	gtk.Main()
 }
+
+// TestVariantContainers ensures that basic values survive a round trip
+// through tuple, array and dictionary containers.
+func TestVariantContainers(t *testing.T) {
+	s := glib.VariantNewString("gotk3")
+	i := glib.VariantNewInt32(42)
+
+	tuple, err := glib.VariantNewTuple(s, i)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ := tuple.TypeString(); typ != "(si)" {
+		t.Fatalf("tuple type is %q, expected (si)", typ)
+	}
+	child, err := tuple.GetChildValue(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.GetInt32() != 42 {
+		t.Error("tuple child does not equal 42")
+	}
+	if _, err := tuple.GetChildValue(2); err == nil {
+		t.Error("expected error for out of range child index")
+	}
+
+	if _, err := glib.VariantNewArray(nil, s, i); err == nil {
+		t.Error("expected error for array of mixed types")
+	}
+
+	dict, err := glib.VariantDictNew(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict.InsertValue("answer", i)
+	asv := dict.End()
+	if v := asv.LookupValue("answer", nil); v == nil || !v.Equal(i) {
+		t.Error("a{sv} lookup did not return the inserted value")
+	}
+	if asv.Print(false) != "{'answer': <42>}" {
+		t.Errorf("unexpected print output %q", asv.Print(false))
+	}
+}
+
+// TestVariantMarshal ensures that Go values are converted to correctly
+// typed Variants by VariantOf and restored by Unmarshal.
+func TestVariantMarshal(t *testing.T) {
+	type point struct {
+		X, Y int32
+	}
+	type settings struct {
+		Title  string  `variant:"title"`
+		Zoom   float64 `variant:"zoom"`
+		Hidden []string
+		Origin point  `variant:"origin"`
+		Cache  string `variant:"-"`
+	}
+
+	tests := []struct {
+		in  interface{}
+		typ string
+	}{
+		{true, "b"},
+		{uint8(1), "y"},
+		{int32(-1), "i"},
+		{42, "x"},
+		{"text", "s"},
+		{[]byte("raw"), "ay"},
+		{[]string{"a", "b"}, "as"},
+		{map[string]interface{}{"k": int32(1)}, "a{sv}"},
+		{point{1, 2}, "(ii)"},
+		{settings{Title: "main"}, "a{sv}"},
+		{(*int32)(nil), "mi"},
+	}
+	for _, test := range tests {
+		v, err := glib.VariantOf(test.in)
+		if err != nil {
+			t.Errorf("VariantOf(%#v): %v", test.in, err)
+			continue
+		}
+		if v.TypeString() != test.typ {
+			t.Errorf("VariantOf(%#v) has type %q, expected %q",
+				test.in, v.TypeString(), test.typ)
+		}
+	}
+
+	in := settings{
+		Title:  "main",
+		Zoom:   1.5,
+		Hidden: []string{"toolbar"},
+		Origin: point{3, 4},
+		Cache:  "ignored",
+	}
+	v, err := glib.VariantOf(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out settings
+	if err := v.Unmarshal(&out); err != nil {
+		t.Fatal(err)
+	}
+	in.Cache = ""
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("Unmarshal returned %#v, expected %#v", out, in)
+	}
+
+	var small int8
+	if err := glib.VariantNewInt32(1000).Unmarshal(&small); err == nil {
+		t.Error("expected overflow error unmarshaling 1000 into int8")
+	}
+	var str string
+	if err := glib.VariantNewBoolean(true).Unmarshal(&str); err == nil {
+		t.Error("expected type error unmarshaling boolean into string")
+	}
+}
+
+// TestVariantParse ensures that printed variants parse back to equal
+// values and that parse errors report the offending position.
+func TestVariantParse(t *testing.T) {
+	in, err := glib.VariantOf(map[string]interface{}{
+		"size":  []int32{800, 600},
+		"title": "main",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := in.Print(true)
+	out, err := glib.VariantParse(nil, text)
+	if err != nil {
+		t.Fatalf("VariantParse(%q): %v", text, err)
+	}
+	if !in.Equal(out) {
+		t.Errorf("parsed %q as %s", text, out.Print(true))
+	}
+
+	data := in.Data()
+	restored, err := glib.VariantNewFromBytes(in.Type(), data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !in.Equal(restored) {
+		t.Error("variant restored from serialized data differs")
+	}
+
+	_, err = glib.VariantParse(nil, "[1, 'two']")
+	perr, ok := err.(*glib.VariantParseError)
+	if !ok {
+		t.Fatalf("expected *VariantParseError, got %v", err)
+	}
+	if len(perr.Ranges) == 0 || perr.Context == "" {
+		t.Errorf("parse error lacks position information: %#v", perr)
+	}
+}
+
+// TestVariantTypeBuilders ensures that composite types built from Go match
+// their type strings and can be taken apart again.
+func TestVariantTypeBuilders(t *testing.T) {
+	if _, err := glib.VariantTypeNew("a{"); err == nil {
+		t.Error("expected error for invalid type string")
+	}
+
+	entry, err := glib.VariantTypeDictEntry(glib.VARIANT_TYPE_STRING, glib.VARIANT_TYPE_VARIANT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict := glib.VariantTypeArray(entry)
+	if !dict.Equal(glib.VARIANT_TYPE_VARDICT) {
+		t.Errorf("built type %s, expected a{sv}", dict)
+	}
+	if !dict.IsSubtypeOf(glib.VARIANT_TYPE_DICTIONARY) {
+		t.Error("a{sv} is not a subtype of a{?*}")
+	}
+	if key := dict.Element().Key(); key.String() != "s" {
+		t.Errorf("dict entry key is %s, expected s", key)
+	}
+
+	tuple := glib.VariantTypeTuple(glib.VARIANT_TYPE_INT32, glib.VARIANT_TYPE_STRING)
+	var items []string
+	for item := tuple.First(); item != nil; item = item.Next() {
+		items = append(items, item.String())
+	}
+	if !reflect.DeepEqual(items, []string{"i", "s"}) {
+		t.Errorf("tuple items are %v, expected [i s]", items)
+	}
+	if _, err := glib.VariantTypeDictEntry(tuple, tuple); err == nil {
+		t.Error("expected error for dict entry with non-basic key")
+	}
+}
+
+// TestMainLoopContext ensures that a MainLoop may run its own context on a
+// separate OS thread, independently of the GTK main loop.
+func TestMainLoopContext(t *testing.T) {
+	ctx, err := glib.MainContextNew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop, err := glib.MainLoopNew(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ctx is not the thread-default context of the test, so the invoked
+	// function must wait for the loop instead of running here.
+	invoked := make(chan bool, 1)
+	ctx.Invoke(func() { invoked <- ctx.IsOwner() })
+	select {
+	case <-invoked:
+		t.Fatal("Invoke ran f before the loop of its context")
+	default:
+	}
+
+	done := make(chan string)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		ctx.PushThreadDefault()
+		defer ctx.PopThreadDefault()
+		loop.Run()
+		done <- "stopped"
+	}()
+
+	ctx.TimeoutAdd(10, func() bool {
+		loop.Quit()
+		return false
+	})
+	select {
+	case msg := <-done:
+		if msg != "stopped" {
+			t.Fatal(msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("loop did not stop after Quit")
+	}
+	select {
+	case owner := <-invoked:
+		if !owner {
+			t.Error("invoked function did not run on the thread of the loop")
+		}
+	default:
+		t.Error("invoked function did not run while the loop was running")
+	}
+	if loop.IsRunning() {
+		t.Error("loop still running after Quit")
+	}
+}
+
+// TestSourceRemove ensures that sources returning true are run repeatedly
+// and that pending sources can be removed by their handle.
+func TestSourceRemove(t *testing.T) {
+	runtime.LockOSThread()
+
+	removed, err := glib.IdleAddFull(glib.PRIORITY_LOW, func() {
+		t.Error("removed idle source was run")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !glib.SourceRemove(removed) {
+		t.Error("SourceRemove did not find pending source")
+	}
+	if glib.SourceRemove(removed) {
+		t.Error("SourceRemove found already removed source")
+	}
+
+	calls := 0
+	glib.TimeoutAddFull(glib.PRIORITY_HIGH, 10, func() bool {
+		calls++
+		if calls == 3 {
+			gtk.MainQuit()
+			return false
+		}
+		return true
+	})
+	gtk.Main()
+
+	if calls != 3 {
+		t.Errorf("timeout ran %d times, expected 3", calls)
+	}
+}
+
+// TestInvokeSync ensures that functions invoked from other goroutines run
+// on the main thread, return their results and report panics as errors,
+// and that functions invoked before the main loop runs are run at once.
+func TestInvokeSync(t *testing.T) {
+	runtime.LockOSThread()
+
+	vals, err := glib.InvokeSync(glib.IsMainThread)
+	if err != nil || !vals[0].(bool) {
+		t.Errorf("InvokeSync before gtk.Main returned %v (%v), expected [true]", vals, err)
+	}
+
+	// The goroutine is started by the main loop so that it is running and
+	// owns the default context.
+	glib.IdleAdd(func() {
+		go func() {
+			defer glib.IdleAdd(gtk.MainQuit)
+
+			vals, err := glib.InvokeSync(func(a, b int) (int, bool) {
+				return a + b, glib.IsMainThread()
+			}, 2, 3)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if vals[0].(int) != 5 || !vals[1].(bool) {
+				t.Errorf("InvokeSync returned %v, expected [5 true]", vals)
+			}
+
+			_, err = glib.InvokeSync(func() { panic("boom") })
+			if perr, ok := err.(*glib.InvokePanicError); !ok || perr.Value != "boom" {
+				t.Errorf("expected *InvokePanicError, got %v", err)
+			}
+		}()
+	})
+	gtk.Main()
+}
+
+// TestTimeoutAddContext ensures that sources added with a context are
+// removed without running once the context is cancelled.
+func TestTimeoutAddContext(t *testing.T) {
+	runtime.LockOSThread()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := glib.TimeoutAddContext(ctx, 50, func() bool {
+		t.Error("source ran after its context was cancelled")
+		return false
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	glib.TimeoutAdd(100, gtk.MainQuit)
+	gtk.Main()
+
+	if _, err := glib.IdleAddContext(ctx, func() {}); err != context.Canceled {
+		t.Errorf("IdleAddContext with done context returned %v, expected %v",
+			err, context.Canceled)
+	}
+}
+
+// TestObjectProperties ensures that properties can be read back after
+// being set and that their param specs describe them.
+func TestObjectProperties(t *testing.T) {
+	box, err := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := box.Set("spacing", 7); err != nil {
+		t.Fatal(err)
+	}
+	spacing, err := box.GetPropertyInt("spacing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spacing != 7 {
+		t.Errorf("spacing is %d, expected 7", spacing)
+	}
+	if _, err := box.GetPropertyString("spacing"); err == nil {
+		t.Error("reading int property as string did not fail")
+	}
+	if _, err := box.GetProperty("no-such-property"); err == nil {
+		t.Error("reading missing property did not fail")
+	}
+
+	var pspec *glib.ParamSpec
+	for _, p := range box.ListProperties() {
+		if p.Name() == "spacing" {
+			pspec = p
+		}
+	}
+	if pspec == nil {
+		t.Fatal("spacing not listed in box properties")
+	}
+	if pspec.ValueType() != glib.TYPE_INT || !pspec.IsWritable() {
+		t.Errorf("unexpected spacing param spec: type %v, flags %v",
+			pspec.ValueType(), pspec.Flags())
+	}
+	if def, err := pspec.DefaultValue(); err != nil || def != 0 {
+		t.Errorf("spacing default is %v (%v), expected 0", def, err)
+	}
+	if min, _, err := pspec.Range(); err != nil || min != 0 {
+		t.Errorf("spacing minimum is %v (%v), expected 0", min, err)
+	}
+}
+
+// TestBindProperty ensures that bound properties are kept in sync through
+// transform functions until they are unbound.
+func TestBindProperty(t *testing.T) {
+	src, _ := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 1)
+	dst, _ := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+
+	double := func(from interface{}) (interface{}, bool) {
+		return from.(int) * 2, true
+	}
+	binding, err := src.BindPropertyFull("spacing", dst, "spacing",
+		glib.BINDING_SYNC_CREATE, double, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spacing, _ := dst.GetPropertyInt("spacing"); spacing != 2 {
+		t.Errorf("target spacing after sync is %d, expected 2", spacing)
+	}
+
+	src.SetSpacing(5)
+	if spacing, _ := dst.GetPropertyInt("spacing"); spacing != 10 {
+		t.Errorf("target spacing after change is %d, expected 10", spacing)
+	}
+
+	binding.Unbind()
+	src.SetSpacing(6)
+	if spacing, _ := dst.GetPropertyInt("spacing"); spacing != 10 {
+		t.Errorf("target spacing after unbind is %d, expected 10", spacing)
+	}
+
+	if _, err := src.BindProperty("no-such-property", dst, "spacing",
+		glib.BINDING_DEFAULT); err == nil {
+		t.Error("binding missing property did not fail")
+	}
+}
+
+type testCounter struct {
+	*glib.Object
+	Count int    `property:"count"`
+	Label string `property:"label"`
+}
+
+// TestRegisterType ensures that types defined in Go store their
+// properties in their Go struct and can emit their own signals.
+func TestRegisterType(t *testing.T) {
+	count, err := glib.ParamSpecInt("count", "Count", "The count",
+		0, 100, 5, glib.PARAM_READWRITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	label, err := glib.ParamSpecString("label", "Label", "The label",
+		"none", glib.PARAM_READWRITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typ, err := glib.RegisterType(&glib.TypeInfo{
+		Name:       "GotkTestCounter",
+		Parent:     glib.TYPE_OBJECT,
+		Instance:   testCounter{},
+		Properties: []*glib.ParamSpec{count, label},
+		Signals: []glib.SignalSpec{
+			{Name: "incremented", Flags: glib.SIGNAL_RUN_LAST,
+				ParamTypes: []glib.Type{glib.TYPE_INT}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if glib.TypeFromName("GotkTestCounter") != typ {
+		t.Error("registered type not found by name")
+	}
+
+	obj, err := glib.ObjectNew(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, ok := obj.Private().(*testCounter)
+	if !ok {
+		t.Fatalf("Private returned %T, expected *testCounter", obj.Private())
+	}
+	if counter.Count != 5 || counter.Label != "none" {
+		t.Errorf("fields not set to defaults: %+v", counter)
+	}
+
+	notified := false
+	obj.Connect("notify::count", func() { notified = true })
+	obj.Set("count", 7)
+	if counter.Count != 7 || !notified {
+		t.Errorf("count is %d after set (notified %v), expected 7",
+			counter.Count, notified)
+	}
+	counter.Label = "seven"
+	if s, _ := obj.GetPropertyString("label"); s != "seven" {
+		t.Errorf("label property is %q, expected \"seven\"", s)
+	}
+
+	var got int
+	obj.Connect("incremented", func(_ *glib.Object, n int) { got = n })
+	counter.Emit("incremented", 3)
+	if got != 3 {
+		t.Errorf("incremented handler received %d, expected 3", got)
+	}
+
+	if _, err := glib.RegisterType(&glib.TypeInfo{Name: "GotkTestCounter",
+		Parent: glib.TYPE_OBJECT}); err == nil {
+		t.Error("registering a duplicate type name did not fail")
+	}
+}
+
+// TestRegisterTypeInstanceInit ensures that the InstanceInit of each Go
+// type in a hierarchy runs once per instance, from the root down, with
+// its own class installed on the instance.
+func TestRegisterTypeInstanceInit(t *testing.T) {
+	var seen []glib.Type
+	record := func(obj *glib.Object) {
+		seen = append(seen, obj.TypeFromInstance())
+	}
+	base, err := glib.RegisterType(&glib.TypeInfo{
+		Name:         "GotkTestInitBase",
+		Parent:       glib.TYPE_OBJECT,
+		InstanceInit: record,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	derived, err := glib.RegisterType(&glib.TypeInfo{
+		Name:         "GotkTestInitDerived",
+		Parent:       base,
+		Instance:     testCounter{},
+		InstanceInit: record,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj, err := glib.ObjectNew(derived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []glib.Type{base, derived}; !reflect.DeepEqual(seen, expected) {
+		t.Errorf("instance inits saw types %v, expected %v", seen, expected)
+	}
+	if _, ok := obj.Private().(*testCounter); !ok {
+		t.Errorf("Private returned %T, expected *testCounter", obj.Private())
+	}
+}
+
+type testNamedCounter struct {
+	testCounter
+	Name string `property:"name"`
+}
+
+// TestRegisterTypeSubclass ensures that a type derived from another Go
+// type stores the properties of both in its struct, and that types which
+// cannot store the properties of their Go parent are rejected.
+func TestRegisterTypeSubclass(t *testing.T) {
+	count, err := glib.ParamSpecInt("count", "Count", "The count",
+		0, 100, 5, glib.PARAM_READWRITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := glib.ParamSpecString("name", "Name", "The name",
+		"anonymous", glib.PARAM_READWRITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base, err := glib.RegisterType(&glib.TypeInfo{
+		Name:       "GotkTestSubclassBase",
+		Parent:     glib.TYPE_OBJECT,
+		Instance:   testCounter{},
+		Properties: []*glib.ParamSpec{count},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	derived, err := glib.RegisterType(&glib.TypeInfo{
+		Name:       "GotkTestSubclassDerived",
+		Parent:     base,
+		Instance:   testNamedCounter{},
+		Properties: []*glib.ParamSpec{name},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj, err := glib.ObjectNew(derived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, ok := obj.Private().(*testNamedCounter)
+	if !ok {
+		t.Fatalf("Private returned %T, expected *testNamedCounter", obj.Private())
+	}
+	if counter.Count != 5 || counter.Name != "anonymous" {
+		t.Errorf("fields not set to defaults: %+v", counter)
+	}
+	if counter.Object == nil || counter.Native() != obj.Native() {
+		t.Error("embedded object of the parent struct is not the instance")
+	}
+
+	if err := obj.Set("count", 8); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := obj.GetPropertyInt("count"); err != nil || n != 8 || counter.Count != 8 {
+		t.Errorf("count is %d (%v), field %d, expected 8", n, err, counter.Count)
+	}
+	obj.Set("name", "eight")
+	if s, _ := obj.GetPropertyString("name"); s != "eight" {
+		t.Errorf("name property is %q, expected \"eight\"", s)
+	}
+
+	if _, err := glib.RegisterType(&glib.TypeInfo{
+		Name:     "GotkTestSubclassMissing",
+		Parent:   base,
+		Instance: struct{ Name string }{},
+	}); err == nil {
+		t.Error("registering a type without the fields of its parent did not fail")
+	}
+}
+
+// TestSignalNew ensures that signals created from Go can be queried and
+// that boolean accumulators stop the emission once a handler returns true.
+func TestSignalNew(t *testing.T) {
+	typ, err := glib.RegisterType(&glib.TypeInfo{
+		Name:   "GotkTestEmitter",
+		Parent: glib.TYPE_OBJECT,
+		Signals: []glib.SignalSpec{{
+			Name:        "handle",
+			Flags:       glib.SIGNAL_RUN_LAST,
+			Accumulator: glib.SIGNAL_ACCUMULATOR_TRUE_HANDLED,
+			ReturnType:  glib.TYPE_BOOLEAN,
+			ParamTypes:  []glib.Type{glib.TYPE_STRING},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj, err := glib.ObjectNew(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := glib.SignalNew("changed", typ, glib.SIGNAL_RUN_FIRST,
+		glib.TYPE_NONE, glib.TYPE_INT, glib.TYPE_STRING)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := glib.SignalLookup("changed", typ); err != nil || id != changed {
+		t.Errorf("SignalLookup returned %d (%v), expected %d", id, err, changed)
+	}
+	if _, err := glib.SignalLookup("no-such-signal", typ); err == nil {
+		t.Error("looking up a missing signal did not fail")
+	}
+	if ids := glib.ListSignalIDs(typ); len(ids) != 2 {
+		t.Errorf("ListSignalIDs returned %v, expected 2 signals", ids)
+	}
+
+	info, err := glib.SignalQuery(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &glib.SignalInfo{
+		ID:         changed,
+		Name:       "changed",
+		OwnerType:  typ,
+		Flags:      glib.SIGNAL_RUN_FIRST,
+		ReturnType: glib.TYPE_NONE,
+		ParamTypes: []glib.Type{glib.TYPE_INT, glib.TYPE_STRING},
+	}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("SignalQuery returned %+v, expected %+v", info, expected)
+	}
+
+	var calls []string
+	obj.Connect("handle", func(_ *glib.Object, s string) bool {
+		calls = append(calls, "first "+s)
+		return true
+	})
+	obj.Connect("handle", func(_ *glib.Object, s string) bool {
+		calls = append(calls, "second "+s)
+		return false
+	})
+	handled, err := obj.Emit("handle", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handled != true || len(calls) != 1 {
+		t.Errorf("emission returned %v after calls %v, expected true after the first",
+			handled, calls)
+	}
+}
+
+// TestConnectVariants ensures that one-shot and swapped handlers are run
+// as documented and that handlers can be disconnected by func.
+func TestConnectVariants(t *testing.T) {
+	box, _ := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+
+	once := 0
+	handle, err := box.ConnectOnce("notify::spacing", func() { once++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	var swapped string
+	box.ConnectSwapped("notify::spacing", func(s string, _ *glib.ParamSpec, obj *glib.Object) {
+		if obj != nil {
+			swapped = s
+		}
+	}, "data")
+	counted := 0
+	count := func() { counted++ }
+	box.Connect("notify::spacing", count)
+	box.ConnectAfter("notify::spacing", count)
+
+	box.SetSpacing(1)
+	box.SetSpacing(2)
+	if once != 1 || box.HandlerIsConnected(handle) {
+		t.Errorf("ConnectOnce handler ran %d times, expected 1", once)
+	}
+	if swapped != "data" {
+		t.Errorf("swapped handler received %q, expected \"data\"", swapped)
+	}
+	if counted != 4 {
+		t.Errorf("handlers ran %d times, expected 4", counted)
+	}
+
+	if n := box.HandlersDisconnectMatched(count); n != 2 {
+		t.Errorf("disconnected %d handlers, expected 2", n)
+	}
+	box.SetSpacing(3)
+	if counted != 4 {
+		t.Errorf("disconnected handlers ran %d times, expected 4", counted)
+	}
+
+	if _, err := box.Connect("no-such-signal", count); err == nil {
+		t.Error("connecting to a missing signal did not fail")
+	}
+}
+
+// dialog is a receiver of method values connected as signal handlers.
+type dialog struct {
+	changed int
+}
+
+func (d *dialog) onChanged() {
+	d.changed++
+}
+
+// TestHandlersMatchedReceivers ensures that disconnecting the method
+// value of one receiver, by func or by user data, leaves the handlers of
+// other receivers of the same method connected.
+func TestHandlersMatchedReceivers(t *testing.T) {
+	box, _ := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+	a, b := &dialog{}, &dialog{}
+	onA, onB := a.onChanged, b.onChanged
+	box.Connect("notify::spacing", onA)
+	box.Connect("notify::spacing", onB)
+
+	if n := box.HandlersDisconnectMatched(onA); n != 1 {
+		t.Errorf("disconnected %d handlers, expected 1", n)
+	}
+	box.SetSpacing(1)
+	if a.changed != 0 || b.changed != 1 {
+		t.Errorf("handlers ran %d and %d times, expected 0 and 1", a.changed, b.changed)
+	}
+
+	if n := box.HandlersBlockMatched(onB); n != 1 {
+		t.Errorf("blocked %d handlers, expected 1", n)
+	}
+	box.SetSpacing(2)
+	box.HandlersUnblockMatched(onB)
+	box.SetSpacing(3)
+	if b.changed != 2 {
+		t.Errorf("handler ran %d times, expected 2", b.changed)
+	}
+	box.HandlersDisconnectMatched(onB)
+
+	box.Connect("notify::spacing", a.onChanged, a)
+	box.Connect("notify::spacing", b.onChanged, b)
+	if n := box.HandlersDisconnectByData(a); n != 1 {
+		t.Errorf("disconnected %d handlers by data, expected 1", n)
+	}
+	if n := box.HandlersBlockByData(b); n != 1 {
+		t.Errorf("blocked %d handlers by data, expected 1", n)
+	}
+	box.SetSpacing(4)
+	box.HandlersUnblockByData(b)
+	box.SetSpacing(5)
+	if a.changed != 0 || b.changed != 3 {
+		t.Errorf("handlers ran %d and %d times, expected 0 and 3", a.changed, b.changed)
+	}
+}
+
+// TestConnectObject ensures that handlers connected with ConnectObject
+// are disconnected once the watched object is destroyed.
+func TestConnectObject(t *testing.T) {
+	box, _ := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+	label, _ := gtk.LabelNew("watched")
+	before := glib.ReadStats()
+
+	called := 0
+	handle, err := box.ConnectObject("notify::spacing", func() { called++ }, label, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	box.SetSpacing(1)
+	if called != 1 || glib.ReadStats().SignalHandlers != before.SignalHandlers+1 {
+		t.Fatalf("handler ran %d times before the object was destroyed", called)
+	}
+
+	label.Destroy()
+	if box.HandlerIsConnected(handle) {
+		t.Error("handler still connected after the object was destroyed")
+	}
+	if stats := glib.ReadStats(); stats.SignalHandlers != before.SignalHandlers {
+		t.Errorf("%d signal handlers after the object was destroyed, expected %d",
+			stats.SignalHandlers, before.SignalHandlers)
+	}
+	box.SetSpacing(2)
+	if called != 1 {
+		t.Errorf("handler ran %d times, expected 1", called)
+	}
+}
+
+// TestConnectChecksSignature ensures that callbacks not matching the
+// signature of a signal are rejected when connected.
+func TestConnectChecksSignature(t *testing.T) {
+	box, _ := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+
+	bad := []interface{}{
+		func(_ *glib.Object, _ *glib.ParamSpec, _ int) {},
+		func(_ string) {},
+		func() bool { return true },
+		"not a func",
+	}
+	for i, f := range bad {
+		if _, err := box.Connect("notify::spacing", f); err == nil {
+			t.Errorf("connecting callback %d did not fail", i)
+		}
+	}
+	if _, err := box.Connect("notify::spacing", func(_ *glib.Object, _ *glib.ParamSpec, s string) {}, 1); err == nil {
+		t.Error("connecting callback with mismatched user data did not fail")
+	}
+	if _, err := box.Connect("notify::spacing", func(_ *glib.Object, _ *glib.ParamSpec, n int) {}, 1); err != nil {
+		t.Error(err)
+	}
+
+	glib.SetDebug(true)
+	defer glib.SetDebug(false)
+	if _, err := glib.IdleAdd(func(s string) bool { return false }, 1); err == nil {
+		t.Error("adding source func with mismatched args did not fail")
+	}
+	if _, err := glib.TimeoutAdd(10, func(s string) {}); err == nil {
+		t.Error("adding source func with missing args did not fail")
+	}
+}
+
+// TestReadStats ensures that disconnected handlers and removed sources
+// are no longer counted as live.
+func TestReadStats(t *testing.T) {
+	box, _ := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+	other, _ := gtk.BoxNew(gtk.ORIENTATION_HORIZONTAL, 0)
+	before := glib.ReadStats()
+
+	// Handler ids are only unique per instance, so the same id may be
+	// returned for both boxes.
+	h1, _ := box.Connect("notify::spacing", func() {})
+	h2, _ := other.Connect("notify::spacing", func() {})
+	src, _ := glib.TimeoutAdd(60000, func() bool { return true })
+
+	stats := glib.ReadStats()
+	if stats.SignalHandlers != before.SignalHandlers+2 ||
+		stats.Sources != before.Sources+1 ||
+		stats.Closures != before.Closures+3 {
+		t.Errorf("stats after connecting are %+v, started at %+v", stats, before)
+	}
+
+	box.HandlerDisconnect(h1)
+	other.HandlerDisconnect(h2)
+	glib.SourceRemove(src)
+	if stats := glib.ReadStats(); stats != before {
+		t.Errorf("stats after disconnecting are %+v, expected %+v", stats, before)
+	}
+}
+
+// TestLogHandler ensures that records written to GLib by a LogHandler are
+// routed back to the slog.Handler set with SetLogHandler and counted.
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	glib.SetLogHandler(slog.NewTextHandler(&buf, nil), nil)
+	defer glib.SetLogHandler(nil, nil)
+
+	warnings := glib.LogCount(glib.LOG_LEVEL_WARNING)
+	logger := slog.New(glib.NewLogHandler("gotk3-test", nil))
+	logger.WithGroup("req").Warn("something happened", "id", 42)
+	logger.Debug("not logged")
+
+	if n := glib.LogCount(glib.LOG_LEVEL_WARNING); n != warnings+1 {
+		t.Errorf("warning count is %d, expected %d", n, warnings+1)
+	}
+	out := buf.String()
+	for _, s := range []string{"level=WARN", `msg="something happened"`,
+		"domain=gotk3-test", "REQ_ID=42"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("log output %q does not contain %q", out, s)
+		}
+	}
+	if strings.Contains(out, "not logged") {
+		t.Errorf("log output %q contains a disabled record", out)
+	}
+}
+
+// TestKeyFile ensures that key files are parsed, modified and serialized
+// and that missing keys are reported with the GLib error codes.
+func TestKeyFile(t *testing.T) {
+	kf, err := glib.KeyFileNew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := "# Top\n[Desktop Entry]\nName=Editor\nName[fr]=Editeur\n" +
+		"Terminal=false\nX-Size=640;480;\n"
+	if err := kf.LoadFromData(data, glib.KEY_FILE_KEEP_COMMENTS|glib.KEY_FILE_KEEP_TRANSLATIONS); err != nil {
+		t.Fatal(err)
+	}
+
+	if g := kf.GetStartGroup(); g != "Desktop Entry" {
+		t.Errorf("start group is %q, expected \"Desktop Entry\"", g)
+	}
+	if name, err := kf.GetLocaleString("Desktop Entry", "Name", "fr"); err != nil || name != "Editeur" {
+		t.Errorf("french name is %q (%v), expected \"Editeur\"", name, err)
+	}
+	if term, err := kf.GetBoolean("Desktop Entry", "Terminal"); err != nil || term {
+		t.Errorf("Terminal is %v (%v), expected false", term, err)
+	}
+	if size, err := kf.GetIntegerList("Desktop Entry", "X-Size"); err != nil ||
+		!reflect.DeepEqual(size, []int{640, 480}) {
+		t.Errorf("X-Size is %v (%v), expected [640 480]", size, err)
+	}
+	if c, err := kf.GetComment("", ""); err != nil || c != " Top\n" {
+		t.Errorf("top comment is %q (%v), expected \" Top\\n\"", c, err)
+	}
+
+	_, err = kf.GetString("Desktop Entry", "Exec")
+	if !errors.Is(err, glib.ErrKeyFileKeyNotFound) {
+		t.Errorf("missing key returned %v, expected ErrKeyFileKeyNotFound", err)
+	}
+	if err := kf.RemoveGroup("No Such Group"); !errors.Is(err, glib.ErrKeyFileGroupNotFound) {
+		t.Errorf("removing missing group returned %v, expected ErrKeyFileGroupNotFound", err)
+	}
+
+	kf.SetDouble("Window", "Scale", 1.5)
+	kf.SetStringList("Window", "Panes", []string{"left", "right"})
+	if err := kf.RemoveKey("Desktop Entry", "X-Size"); err != nil {
+		t.Error(err)
+	}
+	out, err := kf.ToData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "[Window]\nScale=1.5\nPanes=left;right;\n") ||
+		strings.Contains(out, "X-Size") {
+		t.Errorf("unexpected key file data:\n%s", out)
+	}
+}
+
+// TestBytes ensures that copied and pinned byte buffers hold the same
+// data and can be sliced without copying.
+func TestBytes(t *testing.T) {
+	data := []byte("hello, world")
+	copied, err := glib.BytesNew(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned, err := glib.BytesNewPinned(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !copied.Equal(pinned) || copied.Compare(pinned) != 0 || copied.Hash() != pinned.Hash() {
+		t.Error("copied and pinned bytes differ")
+	}
+	if &pinned.UnsafeData()[0] != &data[0] {
+		t.Error("pinned bytes do not share the memory of the slice")
+	}
+	runtime.KeepAlive(pinned)
+
+	hello, err := pinned.NewFromBytes(0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hello.Size() != 5 || string(hello.Data()) != "hello" {
+		t.Errorf("slice of bytes is %q, expected \"hello\"", hello.Data())
+	}
+	if hello.Compare(copied) >= 0 {
+		t.Error("prefix does not compare less than the whole buffer")
+	}
+	if _, err := pinned.NewFromBytes(8, 5); err == nil {
+		t.Error("slicing out of range did not fail")
+	}
+}
+
+// TestListSlices ensures that lists built from slices convert back to the
+// same slices, and that elements are freed with TRANSFER_FULL.
+func TestListSlices(t *testing.T) {
+	in := []int{1, 2, 3}
+	toData := func(i int) uintptr { return uintptr(i) }
+	fromData := func(data uintptr) int { return int(data) }
+
+	list := glib.ListFromSlice(in, toData)
+	if list.Length() != 3 {
+		t.Errorf("list length is %d, expected 3", list.Length())
+	}
+	var freed []int
+	out := glib.ListToSlice(list, glib.TRANSFER_FULL, fromData, func(data uintptr) {
+		freed = append(freed, int(data))
+	})
+	if !reflect.DeepEqual(out, in) || !reflect.DeepEqual(freed, in) {
+		t.Errorf("list converted to %v and freed %v, expected %v", out, freed, in)
+	}
+
+	slist := glib.SListFromSlice(in, toData)
+	if out := glib.SListToSlice(slist, glib.TRANSFER_CONTAINER, fromData, nil); !reflect.DeepEqual(out, in) {
+		t.Errorf("singly-linked list converted to %v, expected %v", out, in)
+	}
+	if out := glib.ListToSlice(nil, glib.TRANSFER_CONTAINER, fromData, nil); len(out) != 0 {
+		t.Errorf("empty list converted to %v", out)
+	}
+}
+
+// TestHashTable ensures that entries inserted in a hash table are found
+// and converted to a map.
+func TestHashTable(t *testing.T) {
+	table, err := glib.HashTableNew(glib.HASH_DIRECT, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uintptr(1); i <= 3; i++ {
+		if !table.Insert(i, i*10) {
+			t.Errorf("key %d was already in the table", i)
+		}
+	}
+	if table.Replace(2, 0) {
+		t.Error("replaced key 2 was not in the table")
+	}
+	if v, ok := table.Lookup(2); !ok || v != 0 {
+		t.Errorf("looked up key 2 with value %d, %v, expected 0, true", v, ok)
+	}
+	if !table.Remove(3) || table.Contains(3) {
+		t.Error("key 3 was not removed")
+	}
+	if _, ok := table.Lookup(3); ok {
+		t.Error("removed key 3 was found")
+	}
+
+	m := glib.HashTableToMap(table, glib.TRANSFER_FULL,
+		func(key uintptr) int { return int(key) },
+		func(value uintptr) int { return int(value) })
+	if expected := map[int]int{1: 10, 2: 0}; !reflect.DeepEqual(m, expected) {
+		t.Errorf("hash table converted to %v, expected %v", m, expected)
+	}
+}
+
+// TestPtrArray ensures that pointer arrays built from slices convert back
+// to the same slices.
+func TestPtrArray(t *testing.T) {
+	in := []int{1, 2, 3}
+	a := glib.PtrArrayFromSlice(in, func(i int) uintptr { return uintptr(i) })
+	if a.Len() != 3 || a.Index(1) != 2 {
+		t.Errorf("array has %d elements, expected 3", a.Len())
+	}
+	a.Add(4)
+	if a.RemoveIndex(0) != 1 || !a.Remove(4) || a.Remove(4) {
+		t.Error("array elements were not removed")
+	}
+
+	out := glib.PtrArrayToSlice(a, glib.TRANSFER_FULL, func(data uintptr) int { return int(data) })
+	if expected := []int{2, 3}; !reflect.DeepEqual(out, expected) {
+		t.Errorf("array converted to %v, expected %v", out, expected)
+	}
+}
+
+// TestDateTime ensures that DateTime converts to and from time.Time
+// without changing the instant or the UTC offset.
+func TestDateTime(t *testing.T) {
+	loc := time.FixedZone("XST", -(3*3600 + 30*60))
+	in := time.Date(2014, time.March, 9, 23, 45, 10, 123456789, loc)
+
+	dt, err := glib.DateTimeFromTime(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y, m, d := dt.GetYmd(); y != 2014 || m != 3 || d != 9 || dt.GetHour() != 23 {
+		t.Errorf("date time is %d-%d-%d %d h, expected 2014-3-9 23 h", y, m, d, dt.GetHour())
+	}
+	if dt.GetUtcOffset() != -(3*time.Hour + 30*time.Minute) {
+		t.Errorf("date time has offset %v, expected -3h30m", dt.GetUtcOffset())
+	}
+	out := dt.Time()
+	if expected := in.Truncate(time.Microsecond); !out.Equal(expected) {
+		t.Errorf("date time converted to %v, expected %v", out, expected)
+	}
+	if _, offset := out.Zone(); offset != -(3*3600 + 30*60) {
+		t.Errorf("converted time has offset %d", offset)
+	}
+
+	next, err := dt.AddDays(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := next.Difference(dt); d != 24*time.Hour {
+		t.Errorf("difference after adding a day is %v", d)
+	}
+	if s, err := next.Format("%Y-%m-%d %H:%M"); err != nil || s != "2014-03-10 23:45" {
+		t.Errorf("formatted date time as %q, %v", s, err)
+	}
+
+	utc, err := glib.DateTimeFromTime(in.UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !utc.Equal(dt) || utc.Time().Location() != time.UTC {
+		t.Error("date time in UTC is not the same instant in UTC")
+	}
+
+	// Named zones keep their location, and so their daylight saving
+	// rules, when both GLib and Go know them.
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("Go time zone database unavailable:", err)
+	}
+	if tz, _ := glib.TimeZoneNew("Europe/Paris"); tz.GetIdentifier() != "Europe/Paris" {
+		t.Skip("GLib does not record time zone identifiers")
+	}
+	before := time.Date(2014, time.March, 29, 12, 0, 0, 0, paris)
+	dt, err = glib.DateTimeFromTime(before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = dt.Time()
+	if !out.Equal(before) || out.Location().String() != "Europe/Paris" {
+		t.Errorf("date time converted to %v in %v, expected %v in Europe/Paris",
+			out, out.Location(), before)
+	}
+	if after := out.Add(24 * time.Hour); after.Hour() != 13 {
+		t.Errorf("adding a day across the DST change gave %v, expected 13:00", after)
+	}
+}
@@ -0,0 +1,44 @@
+//glib_unix : UNIX-specific utilities and integration
+//go:build !windows
+// +build !windows
+
+package glib
+
+// #cgo pkg-config: glib-2.0 gobject-2.0
+// #include <glib.h>
+// #include <glib-object.h>
+// #include <glib-unix.h>
+// #include "glib.go.h"
+import "C"
+import "syscall"
+
+// UnixFDAdd adds a source to the default main event loop context which
+// calls f whenever the file descriptor fd satisfies condition, using
+// g_unix_fd_source_new().  f is passed the descriptor and the conditions
+// which are satisfied, and the source is removed when f returns false.
+// The descriptor is not closed when the source is removed.
+func UnixFDAdd(fd int, condition IOCondition, f func(fd int, condition IOCondition) bool) (SourceHandle, error) {
+	src := C.g_unix_fd_source_new(C.gint(fd), C.GIOCondition(condition))
+	if src == nil {
+		return 0, errNilPtr
+	}
+	return sourceAttachClosure(src, nil, func(fd int, condition uint) bool {
+		return f(fd, IOCondition(condition))
+	})
+}
+
+// UnixSignalAdd adds a source to the default main event loop context
+// which calls f when the process receives signal signum, using
+// g_unix_signal_source_new().  Only SIGHUP, SIGINT, SIGTERM, SIGUSR1,
+// SIGUSR2 and, since GLib 2.54, SIGWINCH are supported.  The source is
+// removed when f returns false.
+//
+// GLib installs its own handler for signum, so the same signal must not
+// also be requested with os/signal.Notify.
+func UnixSignalAdd(signum syscall.Signal, f func() bool) (SourceHandle, error) {
+	src := C.g_unix_signal_source_new(C.gint(signum))
+	if src == nil {
+		return 0, errNilPtr
+	}
+	return sourceAttachClosure(src, nil, f)
+}
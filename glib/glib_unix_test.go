@@ -0,0 +1,113 @@
+//go:build !windows
+// +build !windows
+
+package glib_test
+
+import (
+	"errors"
+	"github.com/conformal/gotk3/glib"
+	"github.com/conformal/gotk3/gtk"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// mainWithTimeout runs gtk.Main and fails the test, quitting the main
+// loop, if it has not been quit after five seconds.
+func mainWithTimeout(t *testing.T) {
+	guard, _ := glib.TimeoutAdd(5000, func() {
+		t.Error("main loop not quit after 5s")
+		gtk.MainQuit()
+	})
+	gtk.Main()
+	glib.SourceRemove(guard)
+}
+
+// TestSpawn ensures that the output and exit status of spawned processes
+// are returned, the latter through a child watch on the main loop.
+func TestSpawn(t *testing.T) {
+	runtime.LockOSThread()
+
+	stdout, _, status, err := glib.SpawnCommandLineSync("echo hello")
+	if err != nil || stdout != "hello\n" || glib.SpawnCheckExitStatus(status) != nil {
+		t.Errorf("spawned command returned %q, %d, %v", stdout, status, err)
+	}
+	_, _, _, err = glib.SpawnCommandLineSync("/nonexistent/gotk3-test")
+	if !errors.Is(err, glib.ErrSpawnNoEnt) {
+		t.Errorf("spawning missing command returned %v, expected ErrSpawnNoEnt", err)
+	}
+
+	pid, stdin, out, stderr, err := glib.SpawnAsyncWithPipes("", []string{"sh", "-c", "echo out; exit 3"},
+		nil, glib.SPAWN_SEARCH_PATH|glib.SPAWN_DO_NOT_REAP_CHILD|glib.SPAWN_STDERR_TO_DEV_NULL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stderr != -1 {
+		t.Errorf("pipe %d created for stderr redirected to /dev/null", stderr)
+	}
+	os.NewFile(uintptr(stdin), "stdin").Close()
+	f := os.NewFile(uintptr(out), "stdout")
+	b, err := io.ReadAll(f)
+	f.Close()
+	if err != nil || string(b) != "out\n" {
+		t.Errorf("read %q, %v from child", b, err)
+	}
+
+	exitStatus := -1
+	glib.ChildWatchAdd(pid, func(watched glib.Pid, status int) {
+		if watched != pid {
+			t.Errorf("child watch called for pid %d, expected %d", watched, pid)
+		}
+		exitStatus = status
+		gtk.MainQuit()
+	})
+	mainWithTimeout(t)
+
+	var gerr *glib.Error
+	if err := glib.SpawnCheckExitStatus(exitStatus); !errors.As(err, &gerr) || gerr.Code != 3 {
+		t.Errorf("child exit status checked as %v, expected exit code 3", err)
+	}
+}
+
+// TestIOAddWatch ensures that lines written to a pipe are read from an
+// IOChannel watched on the main loop, up to the hang up of the pipe.
+func TestIOAddWatch(t *testing.T) {
+	runtime.LockOSThread()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	ch, err := glib.IOChannelNewUnix(int(r.Fd()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	glib.IOAddWatch(ch, glib.IO_IN|glib.IO_HUP, func(ch *glib.IOChannel, condition glib.IOCondition) bool {
+		if condition&glib.IO_IN != 0 {
+			line, status, err := ch.ReadLine()
+			if err != nil {
+				t.Error(err)
+			}
+			if status == glib.IO_STATUS_NORMAL {
+				lines = append(lines, line)
+				return true
+			}
+		}
+		gtk.MainQuit()
+		return false
+	})
+	go func() {
+		w.Write([]byte("first\nsecond\n"))
+		w.Close()
+	}()
+	mainWithTimeout(t)
+
+	if expected := []string{"first\n", "second\n"}; !reflect.DeepEqual(lines, expected) {
+		t.Errorf("read lines %q, expected %q", lines, expected)
+	}
+}
@@ -129,14 +129,15 @@ The list is sorted by most recently focused window, such that the first element
 The list that is returned should not be modified in any way. It will only remain valid until the next focus change or window creation or deletion.
 */
 
-func (v *Application) GetWindows() []*Window { //FIXME
-	/*
-		c := C.gtk_application_get_windows(v.native())
-		obj := &glib.Object{glib.ToGObject(unsafe.Pointer(c))}
-		w := wrapWindow(obj)
-		return w
-	*/
-	return nil
+func (v *Application) GetWindows() []*Window {
+	c := C.gtk_application_get_windows(v.native())
+	wlist := (*glib.List)(unsafe.Pointer(c))
+	return glib.ListToSlice(wlist, glib.TRANSFER_NONE, func(data uintptr) *Window {
+		obj := &glib.Object{glib.ToGObject(unsafe.Pointer(data))}
+		obj.Ref()
+		runtime.SetFinalizer(obj, (*glib.Object).Unref)
+		return wrapWindow(obj)
+	}, nil)
 }
 
 // GetWindowById is a wrapper around gtk_application_get_window_by_id().
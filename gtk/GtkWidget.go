@@ -188,12 +188,6 @@ func (v *Widget) Unmap() {
 //                                    GDestroyNotify notify);
 //void gtk_widget_remove_tick_callback(GtkWidget *widget, guint id);
 
-// TODO(jrick) GtkAllocation
-/*
-func (v *Widget) SizeAllocate() {
-}
-*/
-
 // TODO(jrick) GtkAccelGroup GdkModifierType GtkAccelFlags
 /*
 func (v *Widget) AddAccelerator() {
@@ -592,7 +586,6 @@ func (v *Widget) InitTemplate() {
 	C.gtk_widget_init_template(v.native())
 }
 
-/* FIXME
 //void
 //gtk_widget_class_set_template (GtkWidgetClass *widget_class,
 //                               GBytes *template_bytes);
@@ -600,14 +593,18 @@ func (v *Widget) InitTemplate() {
 //This should be called at class initialization time to specify the GtkBuilder XML to be used to extend a widget.
 //For convenience, gtk_widget_class_set_template_from_resource() is also provided.
 //Note that any class that installs templates must call gtk_widget_init_template() in the widget’s instance initializer.
-func (v *Widget) SetTemplate(template_bytes []byte) {
-	gtemplate_bytes := make([]C.Gbytes, len(template_bytes))
-	defer C.free(unsafe.Pointer(gtemplate_bytes))
-	C.gtk_widget_class_set_template(v.native(), gtemplate_bytes)
+//Widget types registered with RegisterWidgetType call InitTemplate automatically.
+func (v *WidgetClass) SetTemplate(template []byte) {
+	var p C.gconstpointer
+	if len(template) > 0 {
+		p = C.gconstpointer(unsafe.Pointer(&template[0]))
+	}
+	bytes := C.g_bytes_new(p, C.gsize(len(template)))
+	defer C.g_bytes_unref(bytes)
+	C.gtk_widget_class_set_template(v.native(), bytes)
+	v.template = true
 }
-*/
 
-/* FIXME
 //void
 //gtk_widget_class_set_template_from_resource
 //                               (GtkWidgetClass *widget_class,
@@ -615,15 +612,13 @@ func (v *Widget) SetTemplate(template_bytes []byte) {
 
 //A convenience function to call gtk_widget_class_set_template().
 //Note that any class that installs templates must call gtk_widget_init_template() in the widget’s instance initializer.
-func (v *Widget) SetTemplateFromResource(resource_name string) {
+func (v *WidgetClass) SetTemplateFromResource(resource_name string) {
 	cstr := C.CString(resource_name)
 	defer C.free(unsafe.Pointer(cstr))
 	C.gtk_widget_class_set_template_from_resource(v.native(), (*C.gchar)(cstr))
+	v.template = true
 }
-*/
-
 
-/* FIXME
 //GObject *
 //gtk_widget_get_template_child (GtkWidget *widget,
 //                               GType widget_type,
@@ -632,11 +627,17 @@ func (v *Widget) SetTemplateFromResource(resource_name string) {
 //Fetch an object build from the template XML for widget_type in this widget instance.
 //This will only report children which were previously declared with gtk_widget_class_bind_template_child_full() or one of its variants.
 //This function is only meant to be called for code which is private to the widget_type which declared the child and is meant for language bindings which cannot easily make use of the GObject structure offsets.
-func (v *Widget) GetTemplaceChild(widget_type GType, name string) *glib.Object {
+func (v *Widget) GetTemplateChild(widget_type glib.Type, name string) (*glib.Object, error) {
 	cstr := C.CString(name)
 	defer C.free(unsafe.Pointer(cstr))
-	c := C.gtk_widget_get_template_child(v.native(), GWidgetType, (*C.gchar)(cstr))
-	return Object(c)
+	c := C.gtk_widget_get_template_child(v.native(), C.GType(widget_type), (*C.gchar)(cstr))
+	if c == nil {
+		return nil, nilPtrErr
+	}
+	obj := &glib.Object{glib.ToGObject(unsafe.Pointer(c))}
+	obj.Ref()
+	runtime.SetFinalizer(obj, (*glib.Object).Unref)
+	return obj, nil
 }
 
 //void
@@ -647,15 +648,11 @@ func (v *Widget) GetTemplaceChild(widget_type GType, name string) *glib.Object {
 //                                gssize struct_offset);
 
 //Automatically assign an object declared in the class template XML to be set to a location on a freshly built instance’s private data, or alternatively accessible via gtk_widget_get_template_child().
-//The struct can point either into the public instance, then you should use G_STRUCT_OFFSET(WidgetType, member) for struct_offset , or in the private struct, then you should use G_PRIVATE_OFFSET(WidgetType, member).
-//An explicit strong reference will be held automatically for the duration of your instance’s life cycle, it will be released automatically when GObjectClass.dispose() runs on your instance and if a struct_offset that is != 0 is specified, then the automatic location in your instance public or private data will be set to NULL. You can however access an automated child pointer the first time your classes GObjectClass.dispose() runs, or alternatively in GtkWidgetClass.destroy().
 //If internal_child is specified, GtkBuildableIface.get_internal_child() will be automatically implemented by the GtkWidget class so there is no need to implement it manually.
-//The wrapper macros gtk_widget_class_bind_template_child(), gtk_widget_class_bind_template_child_internal(), gtk_widget_class_bind_template_child_private() and gtk_widget_class_bind_template_child_internal_private() might be more convenient to use.
 //Note that this must be called from a composite widget classes class initializer after calling gtk_widget_class_set_template().
-func (v *Widget) BindTemplateChildFull(name string, internal_child bool, struct_offset uint) {
+//Go widgets have no C instance structure to assign to, so the child is only accessible with GetTemplateChild.
+func (v *WidgetClass) BindTemplateChild(name string, internal_child bool) {
 	cstr := C.CString(name)
 	defer C.free(unsafe.Pointer(cstr))
-	C.gtk_widget_class_bind_template_child_full(v.native(), (*C.gchar)(cstr), gbool(internal_child), (C.gssize)(struct_offset))
+	C.gtk_widget_class_bind_template_child_full(v.native(), (*C.gchar)(cstr), gbool(internal_child), 0)
 }
-
-*/
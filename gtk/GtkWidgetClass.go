@@ -0,0 +1,572 @@
+package gtk
+
+// #cgo pkg-config: gtk+-3.0
+// #include <gtk/gtk.h>
+// #include "gtk.go.h"
+// #include "GtkWidgetClass.go.h"
+import "C"
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"github.com/terrak/gotk3/cairo"
+	"github.com/terrak/gotk3/gdk"
+	"github.com/terrak/gotk3/glib"
+)
+
+var (
+	TYPE_WIDGET       = glib.Type(C.gtk_widget_get_type())
+	TYPE_CONTAINER    = glib.Type(C.gtk_container_get_type())
+	TYPE_BIN          = glib.Type(C.gtk_bin_get_type())
+	TYPE_BOX          = glib.Type(C.gtk_box_get_type())
+	TYPE_DRAWING_AREA = glib.Type(C.gtk_drawing_area_get_type())
+)
+
+/*
+ * GtkAllocation
+ */
+
+// Allocation is a representation of GTK's GtkAllocation.
+type Allocation struct {
+	X, Y, Width, Height int
+}
+
+func (v *Allocation) native() *C.GtkAllocation {
+	return &C.GtkAllocation{
+		x:      C.int(v.X),
+		y:      C.int(v.Y),
+		width:  C.int(v.Width),
+		height: C.int(v.Height),
+	}
+}
+
+func wrapAllocation(c *C.GtkAllocation) *Allocation {
+	return &Allocation{int(c.x), int(c.y), int(c.width), int(c.height)}
+}
+
+// GetAllocation is a wrapper around gtk_widget_get_allocation().
+func (v *Widget) GetAllocation() *Allocation {
+	var c C.GtkAllocation
+	C.gtk_widget_get_allocation(v.native(), &c)
+	return wrapAllocation(&c)
+}
+
+// SetAllocation is a wrapper around gtk_widget_set_allocation().
+func (v *Widget) SetAllocation(allocation *Allocation) {
+	C.gtk_widget_set_allocation(v.native(), allocation.native())
+}
+
+// SizeAllocate is a wrapper around gtk_widget_size_allocate().
+func (v *Widget) SizeAllocate(allocation *Allocation) {
+	C.gtk_widget_size_allocate(v.native(), allocation.native())
+}
+
+// GetPreferredWidth is a wrapper around gtk_widget_get_preferred_width().
+func (v *Widget) GetPreferredWidth() (minimum, natural int) {
+	var min, nat C.gint
+	C.gtk_widget_get_preferred_width(v.native(), &min, &nat)
+	return int(min), int(nat)
+}
+
+// GetPreferredHeight is a wrapper around gtk_widget_get_preferred_height().
+func (v *Widget) GetPreferredHeight() (minimum, natural int) {
+	var min, nat C.gint
+	C.gtk_widget_get_preferred_height(v.native(), &min, &nat)
+	return int(min), int(nat)
+}
+
+// QueueResize is a wrapper around gtk_widget_queue_resize().
+func (v *Widget) QueueResize() {
+	C.gtk_widget_queue_resize(v.native())
+}
+
+// Realize is a wrapper around gtk_widget_realize().
+func (v *Widget) Realize() {
+	C.gtk_widget_realize(v.native())
+}
+
+/*
+ * GtkWidgetClass
+ */
+
+// WidgetClass is a representation of GTK's GtkWidgetClass.  It is passed
+// to the ClassInit function of a WidgetTypeInfo.
+type WidgetClass struct {
+	GtkWidgetClass *C.GtkWidgetClass
+
+	// template is set when a template is assigned to the class, so that
+	// each instance initializes it.
+	template bool
+}
+
+// native returns a pointer to the underlying GtkWidgetClass.
+func (v *WidgetClass) native() *C.GtkWidgetClass {
+	if v == nil {
+		return nil
+	}
+	return v.GtkWidgetClass
+}
+
+// Native returns a pointer to the underlying GtkWidgetClass.
+func (v *WidgetClass) Native() uintptr {
+	return uintptr(unsafe.Pointer(v.native()))
+}
+
+// The Go struct of a widget type registered with RegisterWidgetType
+// overrides a virtual method of GtkWidgetClass by implementing the
+// matching interface below.  Each method receives the widget being
+// operated on.  The implementation of the parent class can be called with
+// the Parent methods of Widget, such as ParentSizeAllocate.
+
+// WidgetDrawer overrides the draw virtual method.
+type WidgetDrawer interface {
+	Draw(widget *Widget, cr *cairo.Context) bool
+}
+
+// WidgetPreferredWidthGetter overrides the get_preferred_width virtual
+// method.
+type WidgetPreferredWidthGetter interface {
+	GetPreferredWidth(widget *Widget) (minimum, natural int)
+}
+
+// WidgetPreferredHeightGetter overrides the get_preferred_height virtual
+// method.
+type WidgetPreferredHeightGetter interface {
+	GetPreferredHeight(widget *Widget) (minimum, natural int)
+}
+
+// WidgetSizeAllocator overrides the size_allocate virtual method.
+// Implementations should call SetAllocation or ParentSizeAllocate.
+type WidgetSizeAllocator interface {
+	SizeAllocate(widget *Widget, allocation *Allocation)
+}
+
+// WidgetRealizer overrides the realize virtual method.  Implementations
+// for widgets without their own GdkWindow should call ParentRealize.
+type WidgetRealizer interface {
+	Realize(widget *Widget)
+}
+
+// WidgetButtonPressHandler overrides the button_press_event virtual
+// method.
+type WidgetButtonPressHandler interface {
+	ButtonPressEvent(widget *Widget, event *gdk.EventButton) bool
+}
+
+// WidgetKeyPressHandler overrides the key_press_event virtual method.
+type WidgetKeyPressHandler interface {
+	KeyPressEvent(widget *Widget, event *gdk.EventKey) bool
+}
+
+// WidgetTypeInfo describes a widget type implemented in Go.  Parent must
+// be TYPE_WIDGET or one of its subtypes, such as TYPE_BIN, TYPE_BOX or
+// TYPE_DRAWING_AREA.
+//
+// ClassInit, if not nil, is called once when the class is created, after
+// the virtual methods implemented by the Go struct have been installed.
+// It is the place to call SetTemplate.
+type WidgetTypeInfo struct {
+	glib.TypeInfo
+	ClassInit func(class *WidgetClass)
+}
+
+// RegisterWidgetType registers a new widget type described by info.  The
+// virtual methods implemented by the Go struct of info are overridden in
+// the new class.
+func RegisterWidgetType(info *WidgetTypeInfo) (glib.Type, error) {
+	if !info.Parent.IsA(TYPE_WIDGET) {
+		return glib.TYPE_INVALID, errors.New("parent of " + info.Name + " is not a widget type")
+	}
+
+	var vfuncs C.guint
+	if info.Instance != nil {
+		vfuncs = widgetVFuncs(info.Instance)
+	}
+
+	class := &WidgetClass{}
+	typeInfo := info.TypeInfo
+	typeInfo.ClassInit = func(p uintptr) {
+		class.GtkWidgetClass = C.toGtkWidgetClass(unsafe.Pointer(p))
+		C._gotk3_widget_class_override(class.native(), vfuncs)
+		if info.ClassInit != nil {
+			info.ClassInit(class)
+		}
+		if info.TypeInfo.ClassInit != nil {
+			info.TypeInfo.ClassInit(p)
+		}
+	}
+	typeInfo.InstanceInit = func(obj *glib.Object) {
+		if class.template {
+			wrapWidget(obj).InitTemplate()
+		}
+		if info.TypeInfo.InstanceInit != nil {
+			info.TypeInfo.InstanceInit(obj)
+		}
+	}
+	t, err := glib.RegisterType(&typeInfo)
+	if err != nil {
+		return t, err
+	}
+
+	wt := widgetType{vfuncs: vfuncs}
+	if info.Instance != nil {
+		wt.structType = reflect.TypeOf(info.Instance)
+		if wt.structType.Kind() == reflect.Ptr {
+			wt.structType = wt.structType.Elem()
+		}
+	}
+	widgetTypes.Lock()
+	widgetTypes.m[t] = wt
+	widgetTypes.Unlock()
+	return t, nil
+}
+
+// widgetVFuncs returns the mask of virtual methods implemented by
+// pointers to the Go struct type of instance.
+func widgetVFuncs(instance interface{}) C.guint {
+	t := reflect.TypeOf(instance)
+	if t.Kind() != reflect.Ptr {
+		t = reflect.PtrTo(t)
+	}
+
+	var vfuncs C.guint
+	implements := func(i interface{}, mask C.guint) {
+		if t.Implements(reflect.TypeOf(i).Elem()) {
+			vfuncs |= mask
+		}
+	}
+	implements((*WidgetDrawer)(nil), C.guint(C.GOTK3_WIDGET_DRAW))
+	implements((*WidgetPreferredWidthGetter)(nil), C.guint(C.GOTK3_WIDGET_GET_PREFERRED_WIDTH))
+	implements((*WidgetPreferredHeightGetter)(nil), C.guint(C.GOTK3_WIDGET_GET_PREFERRED_HEIGHT))
+	implements((*WidgetSizeAllocator)(nil), C.guint(C.GOTK3_WIDGET_SIZE_ALLOCATE))
+	implements((*WidgetRealizer)(nil), C.guint(C.GOTK3_WIDGET_REALIZE))
+	implements((*WidgetButtonPressHandler)(nil), C.guint(C.GOTK3_WIDGET_BUTTON_PRESS_EVENT))
+	implements((*WidgetKeyPressHandler)(nil), C.guint(C.GOTK3_WIDGET_KEY_PRESS_EVENT))
+	return vfuncs
+}
+
+// widgetType is the registration of a widget type defined in Go.
+type widgetType struct {
+	structType reflect.Type
+	vfuncs     C.guint
+}
+
+// vfuncKey identifies a virtual method of a widget.
+type vfuncKey struct {
+	widget *C.GtkWidget
+	vfunc  C.guint
+}
+
+// vfuncFrame records a call of a virtual method overridden in Go.  start
+// is the type from which the override is looked up, and owner the type
+// whose override runs, or TYPE_INVALID until the override is found.
+type vfuncFrame struct {
+	start glib.Type
+	owner glib.Type
+}
+
+var (
+	widgetTypes = struct {
+		sync.RWMutex
+		m map[glib.Type]widgetType
+	}{
+		m: make(map[glib.Type]widgetType),
+	}
+
+	// vfuncFrames holds, for each widget and virtual method, the stack of
+	// overrides running, so that chaining up calls the parent class of
+	// the type owning the running override rather than that of the
+	// widget.
+	vfuncFrames = struct {
+		sync.Mutex
+		m map[vfuncKey][]*vfuncFrame
+	}{
+		m: make(map[vfuncKey][]*vfuncFrame),
+	}
+)
+
+// overrideOwner returns the closest type, from t up, which overrides
+// vfunc in Go, or TYPE_INVALID if there is none.
+func overrideOwner(t glib.Type, vfunc C.guint) glib.Type {
+	widgetTypes.RLock()
+	defer widgetTypes.RUnlock()
+	for ; t != glib.TYPE_INVALID; t = t.Parent() {
+		if wt, ok := widgetTypes.m[t]; ok && wt.vfuncs&vfunc != 0 {
+			return t
+		}
+	}
+	return glib.TYPE_INVALID
+}
+
+// overrideImpl returns the value implementing the overrides of owner in
+// priv, the Go struct of a widget.  This is the struct of owner itself,
+// embedded in priv, if the widget is an instance of a type derived from
+// owner.
+func overrideImpl(priv interface{}, owner glib.Type) interface{} {
+	widgetTypes.RLock()
+	wt := widgetTypes.m[owner]
+	widgetTypes.RUnlock()
+
+	rv := reflect.ValueOf(priv)
+	if wt.structType == nil || !rv.IsValid() || rv.Kind() != reflect.Ptr ||
+		rv.Elem().Type() == wt.structType {
+		return priv
+	}
+	if f, ok := embeddedStruct(rv.Elem(), wt.structType); ok {
+		return f.Addr().Interface()
+	}
+	return priv
+}
+
+// embeddedStruct returns the struct of type t embedded in v, descending
+// into embedded structs.
+func embeddedStruct(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.Anonymous || sf.Type.Kind() != reflect.Struct {
+			continue
+		}
+		if sf.Type == t {
+			return v.Field(i), true
+		}
+		if f, ok := embeddedStruct(v.Field(i), t); ok {
+			return f, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// beginOverride wraps the widget c passed to the virtual method vfunc and
+// finds the override to run.  It returns the widget, the value
+// implementing the override, the type owning it, and a function to call
+// once the override has returned.  The override is looked up from the
+// parent class being chained up to, if any, or else from the class of
+// the widget.
+func beginOverride(c *C.GtkWidget, vfunc C.guint) (*Widget, interface{}, glib.Type, func()) {
+	obj := &glib.Object{glib.ToGObject(unsafe.Pointer(c))}
+	widget, priv := wrapWidget(obj), obj.Private()
+
+	key := vfuncKey{c, vfunc}
+	vfuncFrames.Lock()
+	frames := vfuncFrames.m[key]
+	var f *vfuncFrame
+	pushed := false
+	if n := len(frames); n > 0 && frames[n-1].owner == glib.TYPE_INVALID {
+		f = frames[n-1]
+	} else {
+		f = &vfuncFrame{start: obj.TypeFromInstance()}
+		vfuncFrames.m[key] = append(frames, f)
+		pushed = true
+	}
+	f.owner = overrideOwner(f.start, vfunc)
+	if f.owner == glib.TYPE_INVALID {
+		f.owner = f.start
+	}
+	owner := f.owner
+	vfuncFrames.Unlock()
+
+	end := func() {
+		if pushed {
+			popVFuncFrame(key, f)
+		}
+	}
+	return widget, overrideImpl(priv, owner), owner, end
+}
+
+// chainUp calls call with the type owning the override of vfunc running
+// on v, or the closest type of v overriding vfunc if none is running,
+// while recording that the parent class of that type is being chained up
+// to.
+func (v *Widget) chainUp(vfunc C.guint, call func(owner C.GType)) {
+	c := v.native()
+	key := vfuncKey{c, vfunc}
+	vfuncFrames.Lock()
+	var owner glib.Type
+	if frames := vfuncFrames.m[key]; len(frames) > 0 &&
+		frames[len(frames)-1].owner != glib.TYPE_INVALID {
+		owner = frames[len(frames)-1].owner
+	} else {
+		owner = v.TypeFromInstance()
+		if o := overrideOwner(owner, vfunc); o != glib.TYPE_INVALID {
+			owner = o
+		}
+	}
+	f := &vfuncFrame{start: owner.Parent()}
+	vfuncFrames.m[key] = append(vfuncFrames.m[key], f)
+	vfuncFrames.Unlock()
+
+	defer popVFuncFrame(key, f)
+	call(C.GType(owner))
+}
+
+// popVFuncFrame removes the frame f pushed for key.
+func popVFuncFrame(key vfuncKey, f *vfuncFrame) {
+	vfuncFrames.Lock()
+	defer vfuncFrames.Unlock()
+	frames := vfuncFrames.m[key]
+	for i := len(frames) - 1; i >= 0; i-- {
+		if frames[i] == f {
+			frames = append(frames[:i], frames[i+1:]...)
+			break
+		}
+	}
+	if len(frames) == 0 {
+		delete(vfuncFrames.m, key)
+	} else {
+		vfuncFrames.m[key] = frames
+	}
+}
+
+//export goWidgetDraw
+func goWidgetDraw(c *C.GtkWidget, cr *C.cairo_t) C.gboolean {
+	widget, impl, owner, end := beginOverride(c, C.guint(C.GOTK3_WIDGET_DRAW))
+	defer end()
+	if d, ok := impl.(WidgetDrawer); ok {
+		ctx := cairo.NewContext(uintptr(unsafe.Pointer(cr)), true)
+		return gbool(d.Draw(widget, ctx))
+	}
+	return C._gotk3_widget_parent_draw(C.GType(owner), c, cr)
+}
+
+//export goWidgetGetPreferredWidth
+func goWidgetGetPreferredWidth(c *C.GtkWidget, minimum, natural *C.gint) {
+	widget, impl, owner, end := beginOverride(c, C.guint(C.GOTK3_WIDGET_GET_PREFERRED_WIDTH))
+	defer end()
+	if g, ok := impl.(WidgetPreferredWidthGetter); ok {
+		min, nat := g.GetPreferredWidth(widget)
+		*minimum, *natural = C.gint(min), C.gint(nat)
+		return
+	}
+	C._gotk3_widget_parent_get_preferred_width(C.GType(owner), c, minimum, natural)
+}
+
+//export goWidgetGetPreferredHeight
+func goWidgetGetPreferredHeight(c *C.GtkWidget, minimum, natural *C.gint) {
+	widget, impl, owner, end := beginOverride(c, C.guint(C.GOTK3_WIDGET_GET_PREFERRED_HEIGHT))
+	defer end()
+	if g, ok := impl.(WidgetPreferredHeightGetter); ok {
+		min, nat := g.GetPreferredHeight(widget)
+		*minimum, *natural = C.gint(min), C.gint(nat)
+		return
+	}
+	C._gotk3_widget_parent_get_preferred_height(C.GType(owner), c, minimum, natural)
+}
+
+//export goWidgetSizeAllocate
+func goWidgetSizeAllocate(c *C.GtkWidget, allocation *C.GtkAllocation) {
+	widget, impl, owner, end := beginOverride(c, C.guint(C.GOTK3_WIDGET_SIZE_ALLOCATE))
+	defer end()
+	if a, ok := impl.(WidgetSizeAllocator); ok {
+		a.SizeAllocate(widget, wrapAllocation(allocation))
+		return
+	}
+	C._gotk3_widget_parent_size_allocate(C.GType(owner), c, allocation)
+}
+
+//export goWidgetRealize
+func goWidgetRealize(c *C.GtkWidget) {
+	widget, impl, owner, end := beginOverride(c, C.guint(C.GOTK3_WIDGET_REALIZE))
+	defer end()
+	if r, ok := impl.(WidgetRealizer); ok {
+		r.Realize(widget)
+		return
+	}
+	C._gotk3_widget_parent_realize(C.GType(owner), c)
+}
+
+//export goWidgetButtonPressEvent
+func goWidgetButtonPressEvent(c *C.GtkWidget, event *C.GdkEventButton) C.gboolean {
+	widget, impl, owner, end := beginOverride(c, C.guint(C.GOTK3_WIDGET_BUTTON_PRESS_EVENT))
+	defer end()
+	if h, ok := impl.(WidgetButtonPressHandler); ok {
+		ev := &gdk.EventButton{Event: gdk.WrapEvent(uintptr(unsafe.Pointer(event)))}
+		return gbool(h.ButtonPressEvent(widget, ev))
+	}
+	return C._gotk3_widget_parent_button_press_event(C.GType(owner), c, event)
+}
+
+//export goWidgetKeyPressEvent
+func goWidgetKeyPressEvent(c *C.GtkWidget, event *C.GdkEventKey) C.gboolean {
+	widget, impl, owner, end := beginOverride(c, C.guint(C.GOTK3_WIDGET_KEY_PRESS_EVENT))
+	defer end()
+	if h, ok := impl.(WidgetKeyPressHandler); ok {
+		ev := &gdk.EventKey{Event: gdk.WrapEvent(uintptr(unsafe.Pointer(event)))}
+		return gbool(h.KeyPressEvent(widget, ev))
+	}
+	return C._gotk3_widget_parent_key_press_event(C.GType(owner), c, event)
+}
+
+// The Parent methods below call the implementation of a virtual method in
+// the parent class of the type whose Go override is running on v, which
+// may itself be implemented in Go by an ancestor type.  Called outside of
+// an override, they use the parent class of the closest type of v
+// overriding the virtual method in Go.
+
+// ParentDraw calls the draw virtual method of the parent class.
+func (v *Widget) ParentDraw(cr *cairo.Context) bool {
+	var c C.gboolean
+	v.chainUp(C.guint(C.GOTK3_WIDGET_DRAW), func(owner C.GType) {
+		c = C._gotk3_widget_parent_draw(owner, v.native(),
+			(*C.cairo_t)(unsafe.Pointer(cr.Native())))
+	})
+	return gobool(c)
+}
+
+// ParentGetPreferredWidth calls the get_preferred_width virtual method of
+// the parent class.
+func (v *Widget) ParentGetPreferredWidth() (minimum, natural int) {
+	var min, nat C.gint
+	v.chainUp(C.guint(C.GOTK3_WIDGET_GET_PREFERRED_WIDTH), func(owner C.GType) {
+		C._gotk3_widget_parent_get_preferred_width(owner, v.native(), &min, &nat)
+	})
+	return int(min), int(nat)
+}
+
+// ParentGetPreferredHeight calls the get_preferred_height virtual method
+// of the parent class.
+func (v *Widget) ParentGetPreferredHeight() (minimum, natural int) {
+	var min, nat C.gint
+	v.chainUp(C.guint(C.GOTK3_WIDGET_GET_PREFERRED_HEIGHT), func(owner C.GType) {
+		C._gotk3_widget_parent_get_preferred_height(owner, v.native(), &min, &nat)
+	})
+	return int(min), int(nat)
+}
+
+// ParentSizeAllocate calls the size_allocate virtual method of the parent
+// class.
+func (v *Widget) ParentSizeAllocate(allocation *Allocation) {
+	v.chainUp(C.guint(C.GOTK3_WIDGET_SIZE_ALLOCATE), func(owner C.GType) {
+		C._gotk3_widget_parent_size_allocate(owner, v.native(), allocation.native())
+	})
+}
+
+// ParentRealize calls the realize virtual method of the parent class.
+func (v *Widget) ParentRealize() {
+	v.chainUp(C.guint(C.GOTK3_WIDGET_REALIZE), func(owner C.GType) {
+		C._gotk3_widget_parent_realize(owner, v.native())
+	})
+}
+
+// ParentButtonPressEvent calls the button_press_event virtual method of
+// the parent class.
+func (v *Widget) ParentButtonPressEvent(event *gdk.EventButton) bool {
+	var c C.gboolean
+	v.chainUp(C.guint(C.GOTK3_WIDGET_BUTTON_PRESS_EVENT), func(owner C.GType) {
+		c = C._gotk3_widget_parent_button_press_event(owner, v.native(),
+			(*C.GdkEventButton)(unsafe.Pointer(event.Native())))
+	})
+	return gobool(c)
+}
+
+// ParentKeyPressEvent calls the key_press_event virtual method of the
+// parent class.
+func (v *Widget) ParentKeyPressEvent(event *gdk.EventKey) bool {
+	var c C.gboolean
+	v.chainUp(C.guint(C.GOTK3_WIDGET_KEY_PRESS_EVENT), func(owner C.GType) {
+		c = C._gotk3_widget_parent_key_press_event(owner, v.native(),
+			(*C.GdkEventKey)(unsafe.Pointer(event.Native())))
+	})
+	return gobool(c)
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2013-2014 Conformal Systems <info@conformal.com>
+ *
+ * This file originated from: http://opensource.conformal.com/
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+#include <stdlib.h>
+
+/*
+ * Virtual methods of GtkWidgetClass which may be implemented in Go.
+ */
+
+enum {
+	GOTK3_WIDGET_DRAW			= 1 << 0,
+	GOTK3_WIDGET_GET_PREFERRED_WIDTH	= 1 << 1,
+	GOTK3_WIDGET_GET_PREFERRED_HEIGHT	= 1 << 2,
+	GOTK3_WIDGET_SIZE_ALLOCATE		= 1 << 3,
+	GOTK3_WIDGET_REALIZE			= 1 << 4,
+	GOTK3_WIDGET_BUTTON_PRESS_EVENT		= 1 << 5,
+	GOTK3_WIDGET_KEY_PRESS_EVENT		= 1 << 6
+};
+
+extern gboolean	goWidgetDraw(GtkWidget *, cairo_t *);
+extern void	goWidgetGetPreferredWidth(GtkWidget *, gint *, gint *);
+extern void	goWidgetGetPreferredHeight(GtkWidget *, gint *, gint *);
+extern void	goWidgetSizeAllocate(GtkWidget *, GtkAllocation *);
+extern void	goWidgetRealize(GtkWidget *);
+extern gboolean	goWidgetButtonPressEvent(GtkWidget *, GdkEventButton *);
+extern gboolean	goWidgetKeyPressEvent(GtkWidget *, GdkEventKey *);
+
+static GtkWidgetClass *
+toGtkWidgetClass(void *p)
+{
+	return (GTK_WIDGET_CLASS(p));
+}
+
+static gboolean
+_gotk3_widget_draw(GtkWidget *widget, cairo_t *cr)
+{
+	return (goWidgetDraw(widget, cr));
+}
+
+static void
+_gotk3_widget_get_preferred_width(GtkWidget *widget, gint *minimum,
+    gint *natural)
+{
+	goWidgetGetPreferredWidth(widget, minimum, natural);
+}
+
+static void
+_gotk3_widget_get_preferred_height(GtkWidget *widget, gint *minimum,
+    gint *natural)
+{
+	goWidgetGetPreferredHeight(widget, minimum, natural);
+}
+
+static void
+_gotk3_widget_size_allocate(GtkWidget *widget, GtkAllocation *allocation)
+{
+	goWidgetSizeAllocate(widget, allocation);
+}
+
+static void
+_gotk3_widget_realize(GtkWidget *widget)
+{
+	goWidgetRealize(widget);
+}
+
+static gboolean
+_gotk3_widget_button_press_event(GtkWidget *widget, GdkEventButton *event)
+{
+	return (goWidgetButtonPressEvent(widget, event));
+}
+
+static gboolean
+_gotk3_widget_key_press_event(GtkWidget *widget, GdkEventKey *event)
+{
+	return (goWidgetKeyPressEvent(widget, event));
+}
+
+static void
+_gotk3_widget_class_override(GtkWidgetClass *class, guint vfuncs)
+{
+	if (vfuncs & GOTK3_WIDGET_DRAW)
+		class->draw = _gotk3_widget_draw;
+	if (vfuncs & GOTK3_WIDGET_GET_PREFERRED_WIDTH)
+		class->get_preferred_width = _gotk3_widget_get_preferred_width;
+	if (vfuncs & GOTK3_WIDGET_GET_PREFERRED_HEIGHT)
+		class->get_preferred_height = _gotk3_widget_get_preferred_height;
+	if (vfuncs & GOTK3_WIDGET_SIZE_ALLOCATE)
+		class->size_allocate = _gotk3_widget_size_allocate;
+	if (vfuncs & GOTK3_WIDGET_REALIZE)
+		class->realize = _gotk3_widget_realize;
+	if (vfuncs & GOTK3_WIDGET_BUTTON_PRESS_EVENT)
+		class->button_press_event = _gotk3_widget_button_press_event;
+	if (vfuncs & GOTK3_WIDGET_KEY_PRESS_EVENT)
+		class->key_press_event = _gotk3_widget_key_press_event;
+}
+
+/*
+ * Returns the parent class of type, which owns the running override of a
+ * virtual method, so that the override may chain up.  The parent may
+ * implement the virtual method in Go as well.
+ */
+static GtkWidgetClass *
+_gotk3_parent_widget_class(GType type)
+{
+	GType	parent;
+
+	parent = g_type_parent(type);
+	if (parent == G_TYPE_INVALID || !g_type_is_a(parent, GTK_TYPE_WIDGET))
+		return (NULL);
+	return (GTK_WIDGET_CLASS(g_type_class_peek(parent)));
+}
+
+static gboolean
+_gotk3_widget_parent_draw(GType type, GtkWidget *widget, cairo_t *cr)
+{
+	GtkWidgetClass	*class;
+
+	class = _gotk3_parent_widget_class(type);
+	if (class == NULL || class->draw == NULL)
+		return (FALSE);
+	return (class->draw(widget, cr));
+}
+
+static void
+_gotk3_widget_parent_get_preferred_width(GType type, GtkWidget *widget,
+    gint *minimum, gint *natural)
+{
+	GtkWidgetClass	*class;
+
+	*minimum = *natural = 0;
+	class = _gotk3_parent_widget_class(type);
+	if (class != NULL && class->get_preferred_width != NULL)
+		class->get_preferred_width(widget, minimum, natural);
+}
+
+static void
+_gotk3_widget_parent_get_preferred_height(GType type, GtkWidget *widget,
+    gint *minimum, gint *natural)
+{
+	GtkWidgetClass	*class;
+
+	*minimum = *natural = 0;
+	class = _gotk3_parent_widget_class(type);
+	if (class != NULL && class->get_preferred_height != NULL)
+		class->get_preferred_height(widget, minimum, natural);
+}
+
+static void
+_gotk3_widget_parent_size_allocate(GType type, GtkWidget *widget,
+    GtkAllocation *allocation)
+{
+	GtkWidgetClass	*class;
+
+	class = _gotk3_parent_widget_class(type);
+	if (class != NULL && class->size_allocate != NULL)
+		class->size_allocate(widget, allocation);
+}
+
+static void
+_gotk3_widget_parent_realize(GType type, GtkWidget *widget)
+{
+	GtkWidgetClass	*class;
+
+	class = _gotk3_parent_widget_class(type);
+	if (class != NULL && class->realize != NULL)
+		class->realize(widget);
+}
+
+static gboolean
+_gotk3_widget_parent_button_press_event(GType type, GtkWidget *widget,
+    GdkEventButton *event)
+{
+	GtkWidgetClass	*class;
+
+	class = _gotk3_parent_widget_class(type);
+	if (class == NULL || class->button_press_event == NULL)
+		return (FALSE);
+	return (class->button_press_event(widget, event));
+}
+
+static gboolean
+_gotk3_widget_parent_key_press_event(GType type, GtkWidget *widget,
+    GdkEventKey *event)
+{
+	GtkWidgetClass	*class;
+
+	class = _gotk3_parent_widget_class(type);
+	if (class == NULL || class->key_press_event == NULL)
+		return (FALSE);
+	return (class->key_press_event(widget, event));
+}
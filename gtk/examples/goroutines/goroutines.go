@@ -50,18 +50,21 @@ func main() {
 	// to add a function to run in the GTK main loop when it is in an idle
 	// state.
 	//
-	// Two examples of using glib.IdleAdd() are shown below.  The first runs
-	// a user created function, LabelSetTextIdle, and passes it two
-	// arguments for a label and the text to set it with.  The second calls
-	// (*gtk.Label).SetText directly, passing in only the text as an
-	// argument.
+	// The first example below uses glib.IdleAdd() to run a user created
+	// function, LabelSetTextIdle, and passes it two arguments for a label
+	// and the text to set it with.  If the function passed to
+	// glib.IdleAdd() returns one argument, and that argument is a bool,
+	// this return value will be used in the same manner as a native
+	// g_idle_add() call.  If this return value is false, the function will
+	// be removed from executing in the GTK main loop's idle state.  If the
+	// return value is true, the function will continue to execute when the
+	// GTK main loop is in this state.
 	//
-	// If the function passed to glib.IdleAdd() returns one argument, and
-	// that argument is a bool, this return value will be used in the same
-	// manner as a native g_idle_add() call.  If this return value is false,
-	// the function will be removed from executing in the GTK main loop's
-	// idle state.  If the return value is true, the function will continue
-	// to execute when the GTK main loop is in this state.
+	// The second example uses glib.InvokeSync() to call
+	// (*gtk.Label).SetText directly, passing in only the text as an
+	// argument.  InvokeSync() blocks until the call has run in the GTK main
+	// loop, and returns the function's results along with an error if it
+	// panicked.
 	go func() {
 		for {
 			time.Sleep(time.Second)
@@ -72,9 +75,9 @@ func main() {
 			}
 			nSets++
 			s = fmt.Sprintf("Set a label %d time(s)!", nSets)
-			_, err = glib.IdleAdd(bottomLabel.SetText, s)
+			_, err = glib.InvokeSync(bottomLabel.SetText, s)
 			if err != nil {
-				log.Fatal("IdleAdd() failed:", err)
+				log.Fatal("InvokeSync() failed:", err)
 			}
 			nSets++
 		}
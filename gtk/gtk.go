@@ -1316,6 +1316,26 @@ type Builder struct {
 	*glib.Object
 }
 
+// builderError returns the sentinel error of the GTK_BUILDER_ERROR domain
+// for code.
+func builderError(code C.GtkBuilderError) *glib.Error {
+	return glib.ErrorDomain(uint32(C.gtk_builder_error_quark()), int(code))
+}
+
+// Sentinel errors of the GTK_BUILDER_ERROR domain, returned by the
+// Builder methods adding UI definitions.
+var (
+	ErrBuilderInvalidTypeFunction  = builderError(C.GTK_BUILDER_ERROR_INVALID_TYPE_FUNCTION)
+	ErrBuilderUnhandledTag         = builderError(C.GTK_BUILDER_ERROR_UNHANDLED_TAG)
+	ErrBuilderMissingAttribute     = builderError(C.GTK_BUILDER_ERROR_MISSING_ATTRIBUTE)
+	ErrBuilderInvalidAttribute     = builderError(C.GTK_BUILDER_ERROR_INVALID_ATTRIBUTE)
+	ErrBuilderInvalidTag           = builderError(C.GTK_BUILDER_ERROR_INVALID_TAG)
+	ErrBuilderMissingPropertyValue = builderError(C.GTK_BUILDER_ERROR_MISSING_PROPERTY_VALUE)
+	ErrBuilderInvalidValue         = builderError(C.GTK_BUILDER_ERROR_INVALID_VALUE)
+	ErrBuilderVersionMismatch      = builderError(C.GTK_BUILDER_ERROR_VERSION_MISMATCH)
+	ErrBuilderDuplicateID          = builderError(C.GTK_BUILDER_ERROR_DUPLICATE_ID)
+)
+
 // native() returns a pointer to the underlying GtkBuilder.
 func (b *Builder) native() *C.GtkBuilder {
 	if b == nil || b.GObject == nil {
@@ -1344,33 +1364,34 @@ func BuilderNew() (*Builder, error) {
 	return b, nil
 }
 
-// AddFromFile is a wrapper around gtk_builder_add_from_file().
+// AddFromFile is a wrapper around gtk_builder_add_from_file().  The returned
+// error is a *glib.Error.
 func (b *Builder) AddFromFile(filename string) error {
 	cstr := C.CString(filename)
 	defer C.free(unsafe.Pointer(cstr))
 	var err *C.GError = nil
 	res := C.gtk_builder_add_from_file(b.native(), (*C.gchar)(cstr), &err)
 	if res == 0 {
-		defer C.g_error_free(err)
-		return errors.New(C.GoString((*C.char)(err.message)))
+		return glib.TakeError(unsafe.Pointer(err))
 	}
 	return nil
 }
 
-// AddFromResource is a wrapper around gtk_builder_add_from_resource().
+// AddFromResource is a wrapper around gtk_builder_add_from_resource().  The returned
+// error is a *glib.Error.
 func (b *Builder) AddFromResource(path string) error {
 	cstr := C.CString(path)
 	defer C.free(unsafe.Pointer(cstr))
 	var err *C.GError = nil
 	res := C.gtk_builder_add_from_resource(b.native(), (*C.gchar)(cstr), &err)
 	if res == 0 {
-		defer C.g_error_free(err)
-		return errors.New(C.GoString((*C.char)(err.message)))
+		return glib.TakeError(unsafe.Pointer(err))
 	}
 	return nil
 }
 
-// AddFromString is a wrapper around gtk_builder_add_from_string().
+// AddFromString is a wrapper around gtk_builder_add_from_string().  The returned
+// error is a *glib.Error.
 func (b *Builder) AddFromString(str string) error {
 	cstr := C.CString(str)
 	defer C.free(unsafe.Pointer(cstr))
@@ -1378,8 +1399,7 @@ func (b *Builder) AddFromString(str string) error {
 	var err *C.GError = nil
 	res := C.gtk_builder_add_from_string(b.native(), (*C.gchar)(cstr), length, &err)
 	if res == 0 {
-		defer C.g_error_free(err)
-		return errors.New(C.GoString((*C.char)(err.message)))
+		return glib.TakeError(unsafe.Pointer(err))
 	}
 	return nil
 }
@@ -1785,13 +1805,31 @@ func (v *Calendar) SetDisplayOptions(flags CalendarDisplayOptions) {
 		C.GtkCalendarDisplayOptions(flags))
 }
 
-// GetDate is a wrapper around gtk_calendar_get_date().
+// GetDate is a wrapper around gtk_calendar_get_date().  As in GTK, month
+// is in the range 0 to 11.  GetDateTime should be preferred.
 func (v *Calendar) GetDate() (year, month, day uint) {
 	var cyear, cmonth, cday C.guint
 	C.gtk_calendar_get_date(v.native(), &cyear, &cmonth, &cday)
 	return uint(cyear), uint(cmonth), uint(cday)
 }
 
+// GetDateTime returns the midnight starting the selected date in the
+// time zone tz, or in the local time zone if tz is nil.
+func (v *Calendar) GetDateTime(tz *glib.TimeZone) (*glib.DateTime, error) {
+	year, month, day := v.GetDate()
+	if tz == nil {
+		return glib.DateTimeNewLocal(int(year), int(month)+1, int(day), 0, 0, 0)
+	}
+	return glib.DateTimeNew(tz, int(year), int(month)+1, int(day), 0, 0, 0)
+}
+
+// SelectDateTime selects the date of dt, in its own time zone.
+func (v *Calendar) SelectDateTime(dt *glib.DateTime) {
+	year, month, day := dt.GetYmd()
+	v.SelectMonth(uint(month-1), uint(year))
+	v.SelectDay(uint(day))
+}
+
 // TODO gtk_calendar_set_detail_func
 
 // GetDetailWidthChars is a wrapper around gtk_calendar_get_detail_width_chars().
@@ -2541,25 +2579,22 @@ func (v *Container) GetFocusChain() ([]*Widget, bool) {
 	var cwlist *C.GList
 	c := C.gtk_container_get_focus_chain(v.native(), &cwlist)
 
-	var widgets []*Widget
 	wlist := (*glib.List)(unsafe.Pointer(cwlist))
-	for ; wlist.Data != uintptr(unsafe.Pointer(nil)); wlist = wlist.Next {
-		obj := &glib.Object{glib.ToGObject(unsafe.Pointer(wlist.Data))}
-		w := wrapWidget(obj)
-		obj.RefSink()
+	widgets := glib.ListToSlice(wlist, glib.TRANSFER_CONTAINER, func(data uintptr) *Widget {
+		obj := &glib.Object{glib.ToGObject(unsafe.Pointer(data))}
+		obj.Ref()
 		runtime.SetFinalizer(obj, (*glib.Object).Unref)
-		widgets = append(widgets, w)
-	}
+		return wrapWidget(obj)
+	}, nil)
 	return widgets, gobool(c)
 }
 
 // SetFocusChain is a wrapper around gtk_container_set_focus_chain().
 func (v *Container) SetFocusChain(focusableWidgets []IWidget) {
-	var list *glib.List
-	for _, w := range focusableWidgets {
-		data := uintptr(unsafe.Pointer(w.toWidget()))
-		list = list.Append(data)
-	}
+	list := glib.ListFromSlice(focusableWidgets, func(w IWidget) uintptr {
+		return uintptr(unsafe.Pointer(w.toWidget()))
+	})
+	defer list.Free()
 	glist := (*C.GList)(unsafe.Pointer(list))
 	C.gtk_container_set_focus_chain(v.native(), glist)
 }
@@ -7781,11 +7816,7 @@ func (v *TreeSelection) GetSelected(model *ITreeModel, iter *TreeIter) bool {
 }
 
 // GetSelectedRows is a wrapper around gtk_tree_selection_get_selected_rows().
-//
-// Please note that a runtime finalizer is only set on the head of the linked
-// list, and must be kept live while accessing any item in the list, or the
-// Go garbage collector will free the whole list.
-func (v *TreeSelection) GetSelectedRows(model ITreeModel) *glib.List {
+func (v *TreeSelection) GetSelectedRows(model ITreeModel) []*TreePath {
 	var pcmodel **C.GtkTreeModel
 	if model != nil {
 		cmodel := model.toTreeModel()
@@ -7793,11 +7824,14 @@ func (v *TreeSelection) GetSelectedRows(model ITreeModel) *glib.List {
 	}
 	clist := C.gtk_tree_selection_get_selected_rows(v.native(), pcmodel)
 	glist := (*glib.List)(unsafe.Pointer(clist))
-	runtime.SetFinalizer(glist, func(glist *glib.List) {
-		C.g_list_free_full((*C.GList)(unsafe.Pointer(glist)),
-			(C.GDestroyNotify)(C.gtk_tree_path_free))
+	return glib.ListToSlice(glist, glib.TRANSFER_FULL, func(data uintptr) *TreePath {
+		c := C.gtk_tree_path_copy((*C.GtkTreePath)(unsafe.Pointer(data)))
+		path := &TreePath{c}
+		runtime.SetFinalizer(path, (*TreePath).free)
+		return path
+	}, func(data uintptr) {
+		C.gtk_tree_path_free((*C.GtkTreePath)(unsafe.Pointer(data)))
 	})
-	return glist
 }
 
 // CountSelectedRows() is a wrapper around gtk_tree_selection_count_selected_rows().
@@ -8159,12 +8193,6 @@ func (v *Widget) Unmap() {
 //                                    GDestroyNotify notify);
 //void gtk_widget_remove_tick_callback(GtkWidget *widget, guint id);
 
-// TODO(jrick) GtkAllocation
-/*
-func (v *Widget) SizeAllocate() {
-}
-*/
-
 // TODO(jrick) GtkAccelGroup GdkModifierType GtkAccelFlags
 /*
 func (v *Widget) AddAccelerator() {
@@ -9003,8 +9031,7 @@ func (v *Window) SetIconFromFile(file string) error {
 	var err *C.GError = nil
 	res := C.gtk_window_set_icon_from_file(v.native(), (*C.gchar)(cstr), &err)
 	if res == 0 {
-		defer C.g_error_free(err)
-		return errors.New(C.GoString((*C.char)(err.message)))
+		return glib.TakeError(unsafe.Pointer(err))
 	}
 	return nil
 }
@@ -19,6 +19,7 @@
 package gtk
 
 import (
+	"errors"
 	"fmt"
 	"github.com/terrak/gotk3/glib"
 	"log"
@@ -636,3 +637,187 @@ func TestListStoreInsertAfter_WhenNilSibling(t *testing.T) {
 		t.Fatal("Expected the new iter was prepended to liststore")
 	}
 }
+
+type testSquare struct {
+	size int
+}
+
+func (v *testSquare) GetPreferredWidth(widget *Widget) (int, int) {
+	return v.size, v.size * 2
+}
+
+// TestRegisterWidgetType tests overriding widget virtual methods with Go
+// methods, and chaining up to the parent class for the others.
+func TestRegisterWidgetType(t *testing.T) {
+	typ, err := RegisterWidgetType(&WidgetTypeInfo{
+		TypeInfo: glib.TypeInfo{
+			Name:     "GotkTestSquare",
+			Parent:   TYPE_DRAWING_AREA,
+			Instance: testSquare{},
+			InstanceInit: func(obj *glib.Object) {
+				obj.Private().(*testSquare).size = 10
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj, err := glib.ObjectNew(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	widget := wrapWidget(obj)
+	if min, nat := widget.GetPreferredWidth(); min != 10 || nat != 20 {
+		t.Errorf("preferred width is %d, %d, expected 10, 20", min, nat)
+	}
+	widget.SetSizeRequest(-1, 5)
+	if min, _ := widget.GetPreferredHeight(); min != 5 {
+		t.Errorf("preferred height is %d, expected 5 from parent class", min)
+	}
+
+	if _, err := RegisterWidgetType(&WidgetTypeInfo{
+		TypeInfo: glib.TypeInfo{Name: "GotkTestNotAWidget", Parent: glib.TYPE_OBJECT},
+	}); err == nil {
+		t.Error("registering a widget type with a non-widget parent did not fail")
+	}
+}
+
+type testBorderedSquare struct {
+	testSquare
+}
+
+func (v *testBorderedSquare) GetPreferredWidth(widget *Widget) (int, int) {
+	min, nat := widget.ParentGetPreferredWidth()
+	return min + 1, nat + 1
+}
+
+// TestRegisterWidgetTypeChainUp tests that a widget type derived from
+// another Go widget type chains up to the override of its Go parent.
+func TestRegisterWidgetTypeChainUp(t *testing.T) {
+	base, err := RegisterWidgetType(&WidgetTypeInfo{
+		TypeInfo: glib.TypeInfo{
+			Name:     "GotkTestChainBase",
+			Parent:   TYPE_DRAWING_AREA,
+			Instance: testSquare{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	derived, err := RegisterWidgetType(&WidgetTypeInfo{
+		TypeInfo: glib.TypeInfo{
+			Name:     "GotkTestChainDerived",
+			Parent:   base,
+			Instance: testBorderedSquare{},
+			InstanceInit: func(obj *glib.Object) {
+				obj.Private().(*testBorderedSquare).size = 10
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj, err := glib.ObjectNew(derived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	widget := wrapWidget(obj)
+	if min, nat := widget.GetPreferredWidth(); min != 11 || nat != 21 {
+		t.Errorf("preferred width is %d, %d, expected 11, 21", min, nat)
+	}
+	if min, nat := widget.GetPreferredWidth(); min != 11 || nat != 21 {
+		t.Errorf("preferred width is %d, %d on second call, expected 11, 21", min, nat)
+	}
+}
+
+// TestBuilderErrors ensures that errors adding UI definitions are
+// returned as a *glib.Error matching the sentinel of their domain.
+func TestBuilderErrors(t *testing.T) {
+	b, err := BuilderNew()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = b.AddFromFile("/nonexistent/gotk3-test.ui")
+	if !errors.Is(err, glib.ErrFileNoEnt) {
+		t.Errorf("adding missing file returned %v, expected ErrFileNoEnt", err)
+	}
+
+	err = b.AddFromString(`<interface><object class="GtkBox" id="box"/>` +
+		`<object class="GtkBox" id="box"/></interface>`)
+	if !errors.Is(err, ErrBuilderDuplicateID) {
+		t.Errorf("adding duplicate ids returned %v, expected ErrBuilderDuplicateID", err)
+	}
+	var gerr *glib.Error
+	if !errors.As(err, &gerr) || gerr.Message == "" {
+		t.Errorf("error %v is not a *glib.Error with a message", err)
+	}
+}
+
+// TestFocusChain ensures that the focus chain set on a container is
+// returned in the same order.
+func TestFocusChain(t *testing.T) {
+	box, err := BoxNew(ORIENTATION_VERTICAL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := box.GetFocusChain(); ok {
+		t.Error("box without focus chain reported one")
+	}
+
+	var buttons []IWidget
+	for _, label := range []string{"first", "second"} {
+		b, err := ButtonNewWithLabel(label)
+		if err != nil {
+			t.Fatal(err)
+		}
+		box.Add(b)
+		buttons = append([]IWidget{b}, buttons...)
+	}
+	box.SetFocusChain(buttons)
+
+	chain, ok := box.GetFocusChain()
+	if !ok || len(chain) != len(buttons) {
+		t.Fatalf("focus chain has %d widgets, expected %d", len(chain), len(buttons))
+	}
+	for i, w := range chain {
+		if w.toWidget() != buttons[i].toWidget() {
+			t.Errorf("focus chain widget %d is not the expected button", i)
+		}
+	}
+}
+
+// TestGetPropertyWrapped ensures that the typed property getters accept
+// values converted by the gtk marshalers, such as *Adjustment and
+// Orientation.
+func TestGetPropertyWrapped(t *testing.T) {
+	adj, err := AdjustmentNew(0, 0, 100, 1, 10, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw, err := ScrolledWindowNew(adj, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj, err := sw.GetPropertyObject("hadjustment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj == nil || obj.Native() != adj.Native() {
+		t.Error("hadjustment is not the adjustment of the scrolled window")
+	}
+
+	box, err := BoxNew(ORIENTATION_VERTICAL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orientation, err := box.GetPropertyInt("orientation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Orientation(orientation) != ORIENTATION_VERTICAL {
+		t.Errorf("orientation is %d, expected %d", orientation, ORIENTATION_VERTICAL)
+	}
+}